@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"github.com/gershwinlabs/noaa"
 	"log"
-	"math"
 	"net/http"
 	"net/url"
 	"os"
@@ -39,20 +38,10 @@ type Result struct {
 	Value      float64 `json:"value"`
 }
 
+// subTimeSpans splits the overall span into spans of at most a year, the
+// longest the CDO API allows per request.
 func subTimeSpans(overallTimeSpan noaa.TimeSpan) []noaa.TimeSpan {
-	timeSpans := make([]noaa.TimeSpan, 0, 1)
-	durationRemaining := overallTimeSpan.End.Sub(overallTimeSpan.Begin)
-	begin := overallTimeSpan.Begin
-
-	for durationRemaining > 0 {
-		currDuration := time.Duration(math.Min(float64(durationRemaining), float64(365*24*time.Hour)))
-		end := begin.Add(currDuration)
-		timeSpans = append(timeSpans, noaa.TimeSpan{begin, end})
-		durationRemaining = durationRemaining - end.Sub(begin) - (24 * time.Hour)
-		begin = end.Add(24 * time.Hour)
-	}
-
-	return timeSpans
+	return overallTimeSpan.Split(365*24*time.Hour, 24*time.Hour)
 }
 
 func FetchDataFromStationForTimeSpan(station string, overallTimeSpan noaa.TimeSpan, token string) (chan *Result, error) {
@@ -0,0 +1,76 @@
+package cdo
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gershwinlabs/noaa"
+	"github.com/gershwinlabs/noaa/units"
+	"time"
+)
+
+// ghcndVariables maps GHCND datatypes to their variable, the divisor of
+// the stored value and its unit.  GHCND stores most values in tenths.
+var ghcndVariables = map[string]struct {
+	variable noaa.Variable
+	divisor  float64
+	unit     units.Unit
+}{
+	"TMAX": {noaa.MaxTemperature, 10, units.Celsius},
+	"TMIN": {noaa.MinTemperature, 10, units.Celsius},
+	"TAVG": {noaa.Temperature, 10, units.Celsius},
+	"PRCP": {noaa.Precipitation, 10, units.Millimeters},
+	"SNOW": {noaa.Snowfall, 1, units.Millimeters},
+	"SNWD": {noaa.SnowDepth, 1, units.Millimeters},
+	"AWND": {noaa.WindSpeed, 10, units.MetersPerSecond},
+	"WSF2": {noaa.WindGust, 10, units.MetersPerSecond},
+	"WSF5": {noaa.WindGust, 10, units.MetersPerSecond},
+	"WDF2": {noaa.WindDirection, 1, units.Degrees},
+}
+
+// Datum converts a daily GHCND result to a noaa.Datum valid for the day
+// of the result.  The station ID is used as the location ID when loc
+// does not have one.
+func (r Result) Datum(loc noaa.Location) (noaa.Datum, error) {
+	v, ok := ghcndVariables[r.Datatype]
+
+	if !ok {
+		return noaa.Datum{}, errors.New(fmt.Sprintf("No variable for GHCND datatype %s", r.Datatype))
+	}
+
+	day, err := time.ParseInLocation("2006-01-02T15:04:05", r.Date, time.UTC)
+
+	if err != nil {
+		return noaa.Datum{}, err
+	}
+
+	if loc.ID == "" {
+		loc.ID = r.Station
+	}
+
+	return noaa.Datum{
+		Variable:   v.variable,
+		Location:   loc,
+		Valid:      noaa.TimeSpan{Begin: day, End: day.AddDate(0, 0, 1)},
+		Value:      r.Value / v.divisor,
+		Unit:       v.unit,
+		Provenance: noaa.Provenance{Kind: noaa.Observed, Source: "CDO GHCND"},
+	}, nil
+}
+
+// Series converts results to a noaa.Series for each variable.  Results
+// with datatypes that have no variable are skipped.
+func Series(results []Result, loc noaa.Location) []noaa.Series {
+	data := make([]noaa.Datum, 0, len(results))
+
+	for _, r := range results {
+		d, err := r.Datum(loc)
+
+		if err != nil {
+			continue
+		}
+
+		data = append(data, d)
+	}
+
+	return noaa.NewSeries(data)
+}
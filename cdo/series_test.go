@@ -0,0 +1,39 @@
+package cdo
+
+import (
+	"github.com/gershwinlabs/noaa"
+	"github.com/gershwinlabs/noaa/units"
+	"testing"
+	"time"
+)
+
+func TestResultDatum(t *testing.T) {
+	results := []Result{
+		{"", "TMAX", "2014-01-01T00:00:00", "GHCND:USW00094728", 56},
+		{"", "PRCP", "2014-01-01T00:00:00", "GHCND:USW00094728", 25},
+		{"", "TMAX", "2014-01-02T00:00:00", "GHCND:USW00094728", -21},
+		{"", "WT01", "2014-01-02T00:00:00", "GHCND:USW00094728", 1},
+	}
+
+	d, err := results[0].Datum(noaa.Location{Lat: 40.78, Lon: -73.97})
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	day := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if d.Variable != noaa.MaxTemperature || d.Value != 5.6 || d.Unit != units.Celsius || d.Location.ID != "GHCND:USW00094728" || !d.Valid.Begin.Equal(day) || d.Valid.Duration() != 24*time.Hour || d.Provenance.Kind != noaa.Observed {
+		t.Errorf("TMAX converted to %+v", d)
+	}
+
+	if _, err := results[3].Datum(noaa.Location{}); err == nil {
+		t.Errorf("WT01 should not convert")
+	}
+
+	series := Series(results, noaa.Location{})
+
+	if len(series) != 2 || len(series[0].Samples) != 2 || series[1].Variable != noaa.Precipitation || series[1].Samples[0].Value != 2.5 {
+		t.Errorf("Unexpected series %+v", series)
+	}
+}
@@ -0,0 +1,111 @@
+package cdo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gershwinlabs/noaa"
+	"github.com/gershwinlabs/noaa/geo"
+	"github.com/gershwinlabs/noaa/units"
+	"net/http"
+	"net/url"
+)
+
+type Station struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	Latitude      float64 `json:"latitude"`
+	Longitude     float64 `json:"longitude"`
+	Elevation     float64 `json:"elevation"`
+	ElevationUnit string  `json:"elevationUnit"`
+	MinDate       string  `json:"mindate"`
+	MaxDate       string  `json:"maxdate"`
+	DataCoverage  float64 `json:"datacoverage"`
+}
+
+type stations struct {
+	Metadata Metadata  `json:"metadata"`
+	Results  []Station `json:"results"`
+}
+
+// Point returns where the station is, with its elevation in meters.
+func (s Station) Point() geo.Point {
+	elevation, err := units.Convert(s.Elevation, unitOrMeters(s.ElevationUnit), units.Meters)
+
+	if err != nil {
+		elevation = s.Elevation
+	}
+
+	return geo.Point{Lat: s.Latitude, Lon: s.Longitude, Elevation: elevation}
+}
+
+// Location returns the station as a noaa.Location, for use with
+// Result.Datum and Series.
+func (s Station) Location() noaa.Location {
+	return noaa.Location{ID: s.ID, Lat: s.Latitude, Lon: s.Longitude}
+}
+
+func unitOrMeters(s string) units.Unit {
+	u, err := units.Parse(s)
+
+	if err != nil {
+		return units.Meters
+	}
+
+	return u
+}
+
+func stationURL(id string) string {
+	return BASE_URL + "/stations/" + url.PathEscape(id)
+}
+
+// stationsURL returns the URL of the first limit stations of the dataset
+// within box, which CDO calls the extent.
+func stationsURL(datasetID string, box geo.BBox, limit int) string {
+	q := url.Values{}
+	q.Set("datasetid", datasetID)
+	q.Set("extent", fmt.Sprintf("%f,%f,%f,%f", box.South, box.West, box.North, box.East))
+	q.Set("limit", fmt.Sprintf("%d", limit))
+	return BASE_URL + "/stations?" + q.Encode()
+}
+
+func FetchStationWithClient(client *http.Client, id, token string) (Station, error) {
+	var s Station
+	err := getJSON(client, stationURL(id), token, &s)
+	return s, err
+}
+
+// FetchStationsWithClient returns up to 1000 stations with data in the
+// dataset within box.
+func FetchStationsWithClient(client *http.Client, datasetID string, box geo.BBox, token string) ([]Station, error) {
+	var s stations
+
+	if err := getJSON(client, stationsURL(datasetID, box, 1000), token, &s); err != nil {
+		return []Station{}, err
+	}
+
+	return s.Results, nil
+}
+
+func getJSON(client *http.Client, u, token string, v interface{}) error {
+	req, err := http.NewRequest("GET", u, nil)
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("token", token)
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return errors.New(fmt.Sprintf("Received error %d from %s", resp.StatusCode, u))
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
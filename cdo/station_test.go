@@ -0,0 +1,54 @@
+package cdo
+
+import (
+	"encoding/json"
+	"github.com/gershwinlabs/noaa/geo"
+	"math"
+	"net/url"
+	"testing"
+)
+
+const stationJSON = `{"elevation":42.7,"mindate":"1869-01-01","maxdate":"2016-03-01","latitude":40.77898,"name":"NY CITY CENTRAL PARK, NY US","datacoverage":1,"id":"GHCND:USW00094728","elevationUnit":"METERS","longitude":-73.96925}`
+
+func TestStation(t *testing.T) {
+	var s Station
+
+	if err := json.Unmarshal([]byte(stationJSON), &s); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	p := s.Point()
+
+	if p.Lat != 40.77898 || p.Lon != -73.96925 || p.Elevation != 42.7 || p.Validate() != nil {
+		t.Errorf("Station at %+v", p)
+	}
+
+	s.Elevation = 100
+	s.ElevationUnit = "feet"
+
+	if p := s.Point(); math.Abs(p.Elevation-30.48) > 1e-9 {
+		t.Errorf("100 feet is %v meters", p.Elevation)
+	}
+
+	if loc := s.Location(); loc.ID != "GHCND:USW00094728" || loc.Lat != p.Lat {
+		t.Errorf("Station location %+v", loc)
+	}
+}
+
+func TestStationsURL(t *testing.T) {
+	u, err := url.Parse(stationsURL("GHCND", geo.NewBBox(geo.Point{Lat: 40.5, Lon: -74.3}, geo.Point{Lat: 41, Lon: -73.7}), 1000))
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	q := u.Query()
+
+	if u.Path != "/cdo-web/api/v2/stations" || q.Get("extent") != "40.500000,-74.300000,41.000000,-73.700000" || q.Get("datasetid") != "GHCND" {
+		t.Errorf("Unexpected URL %s", u)
+	}
+
+	if stationURL("GHCND:USW00094728") != BASE_URL+"/stations/GHCND:USW00094728" {
+		t.Errorf("Unexpected URL %s", stationURL("GHCND:USW00094728"))
+	}
+}
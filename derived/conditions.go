@@ -0,0 +1,202 @@
+package derived
+
+import (
+	"github.com/gershwinlabs/noaa/ndfd"
+	"github.com/gershwinlabs/noaa/units"
+	"time"
+)
+
+// Hour holds the variables derived at one location and hour that has
+// both a temperature and a dew point.  The wind chill and apparent
+// temperature also need the wind speed, and the wind components the
+// wind direction, so they are only set when HasWind and HasDirection
+// are.
+type Hour struct {
+	Location            string
+	Lat                 float64
+	Lon                 float64
+	Hour                time.Time
+	RelativeHumidity    units.Percentage
+	VaporPressure       units.Pressure
+	HeatIndex           units.Temperature
+	WetBulb             units.Temperature
+	HasWind             bool
+	WindChill           units.Temperature
+	ApparentTemperature units.Temperature
+	HasDirection        bool
+	U                   units.Speed
+	V                   units.Speed
+}
+
+// quantity is any of the units quantity types.
+type quantity interface {
+	In(u units.Unit) (float64, error)
+}
+
+type hourKey struct {
+	location string
+	lat      float64
+	lon      float64
+	hour     int64
+}
+
+type inputs struct {
+	temp, dewpoint *units.Temperature
+	windspeed      *units.Speed
+	winddir        *units.Direction
+	location       string
+	lat, lon       float64
+	hour           time.Time
+}
+
+func keyOf(c ndfd.Condition) hourKey {
+	return hourKey{c.Location, c.Lat, c.Lon, c.Hour.Unix()}
+}
+
+// Derive computes the derived variables for each location and hour of
+// conds with a temp and dewpoint, in the order the temperatures appear.
+// Conditions in units that are not recognized are ignored.
+func Derive(conds []ndfd.Condition) []Hour {
+	keys := make([]hourKey, 0)
+	byKey := make(map[hourKey]*inputs)
+
+	for _, c := range conds {
+		key := keyOf(c)
+		in, ok := byKey[key]
+
+		if !ok {
+			in = &inputs{location: c.Location, lat: c.Lat, lon: c.Lon, hour: c.Hour}
+			byKey[key] = in
+		}
+
+		switch c.Name {
+		case "temp", "dewpoint":
+			t, err := units.NewTemperature(c.Value, c.Unit())
+
+			if err != nil {
+				continue
+			}
+
+			if c.Name == "dewpoint" {
+				in.dewpoint = &t
+				continue
+			}
+
+			if in.temp == nil {
+				keys = append(keys, key)
+			}
+
+			in.temp = &t
+		case "windspeed":
+			s, err := units.NewSpeed(c.Value, c.Unit())
+
+			if err == nil {
+				in.windspeed = &s
+			}
+		case "winddir":
+			d, err := units.NewDirection(c.Value, c.Unit())
+
+			if err == nil {
+				in.winddir = &d
+			}
+		}
+	}
+
+	hours := make([]Hour, 0, len(keys))
+
+	for _, key := range keys {
+		in := byKey[key]
+
+		if in.dewpoint == nil {
+			continue
+		}
+
+		rh := RelativeHumidity(*in.temp, *in.dewpoint)
+		h := Hour{
+			Location:         in.location,
+			Lat:              in.lat,
+			Lon:              in.lon,
+			Hour:             in.hour,
+			RelativeHumidity: rh,
+			VaporPressure:    VaporPressure(*in.dewpoint),
+			HeatIndex:        HeatIndex(*in.temp, rh),
+			WetBulb:          WetBulb(*in.temp, rh),
+		}
+
+		if in.windspeed != nil {
+			h.HasWind = true
+			h.WindChill = WindChill(*in.temp, *in.windspeed)
+			h.ApparentTemperature = ApparentTemperature(*in.temp, rh, *in.windspeed)
+
+			if in.winddir != nil {
+				h.HasDirection = true
+				h.U, h.V = WindComponents(*in.windspeed, *in.winddir)
+			}
+		}
+
+		hours = append(hours, h)
+	}
+
+	return hours
+}
+
+// Conditions returns the derived variables as Conditions in the
+// canonical units of system, named rh, vaporpressure, heatindex,
+// wetbulb, windchill, appt, windu and windv.
+func (h Hour) Conditions(system units.System) []ndfd.Condition {
+	conds := make([]ndfd.Condition, 0, 8)
+	add := func(name string, v float64, u units.Unit) {
+		conds = append(conds, ndfd.Condition{Name: name, Value: v, Units: u.String(), Hour: h.Hour, Lat: h.Lat, Lon: h.Lon, Location: h.Location})
+	}
+
+	temperature := system.Canonical(units.KindTemperature)
+	speed := system.Canonical(units.KindSpeed)
+	in := func(q quantity, u units.Unit) float64 {
+		v, _ := q.In(u)
+		return v
+	}
+
+	add("rh", float64(h.RelativeHumidity), units.Percent)
+	add("vaporpressure", in(h.VaporPressure, system.Canonical(units.KindPressure)), system.Canonical(units.KindPressure))
+	add("heatindex", in(h.HeatIndex, temperature), temperature)
+	add("wetbulb", in(h.WetBulb, temperature), temperature)
+
+	if h.HasWind {
+		add("windchill", in(h.WindChill, temperature), temperature)
+		add("appt", in(h.ApparentTemperature, temperature), temperature)
+	}
+
+	if h.HasDirection {
+		add("windu", in(h.U, speed), speed)
+		add("windv", in(h.V, speed), speed)
+	}
+
+	return conds
+}
+
+// Conditions derives variables from conds and returns them as
+// additional Conditions.  Hours that already have an rh forecast keep
+// it, and no derived rh is added for them.
+func Conditions(conds []ndfd.Condition, system units.System) []ndfd.Condition {
+	forecastRH := make(map[hourKey]bool)
+
+	for _, c := range conds {
+		if c.Name == "rh" {
+			forecastRH[keyOf(c)] = true
+		}
+	}
+
+	derived := make([]ndfd.Condition, 0)
+
+	for _, h := range Derive(conds) {
+		for _, c := range h.Conditions(system) {
+			if c.Name == "rh" && forecastRH[keyOf(c)] {
+				continue
+			}
+
+			derived = append(derived, c)
+		}
+	}
+
+	return derived
+}
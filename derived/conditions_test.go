@@ -0,0 +1,81 @@
+package derived
+
+import (
+	"github.com/gershwinlabs/noaa/ndfd"
+	"github.com/gershwinlabs/noaa/units"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDerive(t *testing.T) {
+	hour := time.Date(2016, 3, 2, 0, 0, 0, 0, time.UTC)
+	conds := []ndfd.Condition{
+		{Name: "temp", Value: 86, Units: "Fahrenheit", Hour: hour, Lat: 39.64, Lon: -106.37, Location: "point1"},
+		{Name: "temp", Value: 20, Units: "Celsius", Hour: hour.Add(time.Hour), Lat: 39.64, Lon: -106.37, Location: "point1"},
+		{Name: "temp", Value: 5, Units: "Celsius", Hour: hour.Add(2 * time.Hour), Lat: 39.64, Lon: -106.37, Location: "point1"},
+		{Name: "dewpoint", Value: 20, Units: "Celsius", Hour: hour, Lat: 39.64, Lon: -106.37, Location: "point1"},
+		{Name: "dewpoint", Value: 20, Units: "Celsius", Hour: hour.Add(time.Hour), Lat: 39.64, Lon: -106.37, Location: "point1"},
+		{Name: "windspeed", Value: 10, Units: "knots", Hour: hour, Lat: 39.64, Lon: -106.37, Location: "point1"},
+		{Name: "winddir", Value: 180, Units: "degrees true", Hour: hour, Lat: 39.64, Lon: -106.37, Location: "point1"},
+		{Name: "rh", Value: 100, Units: "percent", Hour: hour.Add(time.Hour), Lat: 39.64, Lon: -106.37, Location: "point1"},
+	}
+
+	hours := Derive(conds)
+
+	if len(hours) != 2 || !hours[0].Hour.Equal(hour) || !hours[0].HasDirection || hours[1].HasWind {
+		t.Fatalf("Derived %+v", hours)
+	}
+
+	if math.Abs(float64(hours[1].RelativeHumidity)-100) > 1e-9 || math.Abs(float64(hours[0].V)-10*1852.0/3600) > 1e-9 {
+		t.Errorf("Derived %+v", hours)
+	}
+
+	derived := Conditions(conds, units.English)
+	names := make([]string, len(derived))
+
+	for i, c := range derived {
+		names[i] = c.Name
+	}
+
+	expected := []string{"rh", "vaporpressure", "heatindex", "wetbulb", "windchill", "appt", "windu", "windv", "vaporpressure", "heatindex", "wetbulb"}
+
+	if !slices.Equal(names, expected) {
+		t.Errorf("Derived conditions %v", names)
+	}
+
+	if derived[2].Units != "Fahrenheit" || derived[2].Value < 86 || derived[1].Units != "inches of mercury" || derived[7].Units != "knots" || math.Abs(derived[7].Value-10) > 1e-9 {
+		t.Errorf("Derived conditions %+v", derived)
+	}
+}
+
+func TestDeriveDWML(t *testing.T) {
+	f, err := os.Open(filepath.Join("..", "ndfd", "testdata", "time-series.xml"))
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	defer f.Close()
+	dwml, err := ndfd.ParseDWML(f)
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	conds := slices.Collect(dwml.Table().All())
+	hours := Derive(conds)
+
+	if len(hours) != 16 {
+		t.Fatalf("Derived %d hours", len(hours))
+	}
+
+	for _, h := range hours {
+		if !h.HasDirection || h.RelativeHumidity <= 0 || h.RelativeHumidity > 100 || h.WetBulb <= 0 {
+			t.Errorf("Derived %+v", h)
+		}
+	}
+}
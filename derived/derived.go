@@ -0,0 +1,119 @@
+package derived
+
+import (
+	"github.com/gershwinlabs/noaa/units"
+	"math"
+)
+
+func celsius(t units.Temperature) float64 {
+	c, _ := t.In(units.Celsius)
+	return c
+}
+
+func fahrenheit(t units.Temperature) float64 {
+	f, _ := t.In(units.Fahrenheit)
+	return f
+}
+
+func fromCelsius(c float64) units.Temperature {
+	t, _ := units.NewTemperature(c, units.Celsius)
+	return t
+}
+
+func fromFahrenheit(f float64) units.Temperature {
+	t, _ := units.NewTemperature(f, units.Fahrenheit)
+	return t
+}
+
+// VaporPressure returns the saturation vapor pressure over water at t
+// using Bolton's formula.  The vapor pressure of air is the saturation
+// vapor pressure at its dew point.
+func VaporPressure(t units.Temperature) units.Pressure {
+	c := celsius(t)
+	p, _ := units.NewPressure(6.112*math.Exp(17.67*c/(c+243.5)), units.Hectopascals)
+	return p
+}
+
+// RelativeHumidity returns the relative humidity of air at temp with
+// the given dew point.  A dew point above the temperature, which
+// forecasts rounded separately can have, is saturated air at 100%.
+func RelativeHumidity(temp, dewpoint units.Temperature) units.Percentage {
+	rh := 100 * float64(VaporPressure(dewpoint)) / float64(VaporPressure(temp))
+	return units.Percentage(math.Min(rh, 100))
+}
+
+// HeatIndex returns the NWS heat index.  Steadman's simple formula is
+// used when it averages below 80°F with the temperature, and otherwise
+// the Rothfusz regression, adjusted for low humidity between 80°F and
+// 112°F and for high humidity between 80°F and 87°F.
+func HeatIndex(temp units.Temperature, rh units.Percentage) units.Temperature {
+	t := fahrenheit(temp)
+	r := float64(rh)
+	hi := 0.5 * (t + 61 + (t-68)*1.2 + r*0.094)
+
+	if (hi+t)/2 < 80 {
+		return fromFahrenheit(hi)
+	}
+
+	hi = -42.379 + 2.04901523*t + 10.14333127*r - 0.22475541*t*r -
+		0.00683783*t*t - 0.05481717*r*r + 0.00122874*t*t*r +
+		0.00085282*t*r*r - 0.00000199*t*t*r*r
+
+	switch {
+	case r < 13 && t >= 80 && t <= 112:
+		hi -= (13 - r) / 4 * math.Sqrt((17-math.Abs(t-95))/17)
+	case r > 85 && t >= 80 && t <= 87:
+		hi += (r - 85) / 10 * (87 - t) / 5
+	}
+
+	return fromFahrenheit(hi)
+}
+
+// WindChill returns the NWS wind chill, which is only defined at or
+// below 50°F with winds above 3 mph.  Otherwise it returns temp.
+func WindChill(temp units.Temperature, wind units.Speed) units.Temperature {
+	t := fahrenheit(temp)
+	mph, _ := wind.In(units.MilesPerHour)
+
+	if t > 50 || mph <= 3 {
+		return temp
+	}
+
+	v := math.Pow(mph, 0.16)
+	return fromFahrenheit(35.74 + 0.6215*t - 35.75*v + 0.4275*t*v)
+}
+
+// ApparentTemperature returns the NWS apparent temperature: the wind
+// chill at or below 50°F, the heat index above 80°F, and otherwise the
+// temperature.
+func ApparentTemperature(temp units.Temperature, rh units.Percentage, wind units.Speed) units.Temperature {
+	t := fahrenheit(temp)
+
+	switch {
+	case t <= 50:
+		return WindChill(temp, wind)
+	case t > 80:
+		return HeatIndex(temp, rh)
+	}
+
+	return temp
+}
+
+// WetBulb returns the wet-bulb temperature at sea level pressure using
+// Stull's 2011 approximation, which is accurate to within 1°C for
+// relative humidities of 5% to 99% and temperatures of -20°C to 50°C.
+func WetBulb(temp units.Temperature, rh units.Percentage) units.Temperature {
+	t := celsius(temp)
+	r := float64(rh)
+	tw := t*math.Atan(0.151977*math.Sqrt(r+8.313659)) + math.Atan(t+r) - math.Atan(r-1.676331) +
+		0.00391838*math.Pow(r, 1.5)*math.Atan(0.023101*r) - 4.686035
+
+	return fromCelsius(tw)
+}
+
+// WindComponents returns the eastward u and northward v components of a
+// wind blowing from dir.
+func WindComponents(speed units.Speed, dir units.Direction) (u, v units.Speed) {
+	rad := float64(dir) * math.Pi / 180
+	return units.Speed(-float64(speed) * math.Sin(rad)), units.Speed(-float64(speed) * math.Cos(rad))
+}
@@ -0,0 +1,75 @@
+package derived
+
+import (
+	"github.com/gershwinlabs/noaa/units"
+	"math"
+	"testing"
+)
+
+func TestFormulas(t *testing.T) {
+	c := func(v float64) units.Temperature {
+		t, _ := units.NewTemperature(v, units.Celsius)
+		return t
+	}
+
+	f := func(v float64) units.Temperature {
+		t, _ := units.NewTemperature(v, units.Fahrenheit)
+		return t
+	}
+
+	mph := func(v float64) units.Speed {
+		s, _ := units.NewSpeed(v, units.MilesPerHour)
+		return s
+	}
+
+	tests := []struct {
+		name     string
+		actual   func() (float64, error)
+		expected float64
+		within   float64
+	}{
+		{"vapor pressure", func() (float64, error) { return VaporPressure(c(20)).In(units.Hectopascals) }, 23.37, 0.01},
+		{"saturated rh", func() (float64, error) { return float64(RelativeHumidity(c(20), c(20))), nil }, 100, 1e-9},
+		{"rh", func() (float64, error) { return float64(RelativeHumidity(c(30), c(10))), nil }, 28.9, 0.01},
+		{"supersaturated rh", func() (float64, error) { return float64(RelativeHumidity(c(20), c(21))), nil }, 100, 1e-9},
+		{"simple heat index", func() (float64, error) { return HeatIndex(f(70), 50).In(units.Fahrenheit) }, 69.05, 1e-6},
+		{"heat index", func() (float64, error) { return HeatIndex(f(90), 70).In(units.Fahrenheit) }, 105.9, 0.1},
+		{"dry heat index", func() (float64, error) { return HeatIndex(f(100), 10).In(units.Fahrenheit) }, 94.12, 0.01},
+		{"humid heat index", func() (float64, error) { return HeatIndex(f(84), 95).In(units.Fahrenheit) }, 100.88, 0.01},
+		{"wind chill", func() (float64, error) { return WindChill(f(0), mph(15)).In(units.Fahrenheit) }, -19.4, 0.1},
+		{"calm wind chill", func() (float64, error) { return WindChill(f(0), mph(2)).In(units.Fahrenheit) }, 0, 1e-9},
+		{"warm wind chill", func() (float64, error) { return WindChill(f(60), mph(20)).In(units.Fahrenheit) }, 60, 1e-9},
+		{"mild apparent", func() (float64, error) { return ApparentTemperature(f(70), 90, mph(20)).In(units.Fahrenheit) }, 70, 1e-9},
+		{"cold apparent", func() (float64, error) { return ApparentTemperature(f(0), 50, mph(15)).In(units.Fahrenheit) }, -19.4, 0.1},
+		{"hot apparent", func() (float64, error) { return ApparentTemperature(f(90), 70, mph(15)).In(units.Fahrenheit) }, 105.9, 0.1},
+		{"wet bulb", func() (float64, error) { return WetBulb(c(20), 50).In(units.Celsius) }, 13.7, 0.05},
+	}
+
+	for _, test := range tests {
+		v, err := test.actual()
+
+		if err != nil || math.Abs(v-test.expected) > test.within {
+			t.Errorf("%s computed as %v %v, expected %v", test.name, v, err, test.expected)
+		}
+	}
+}
+
+func TestWindComponents(t *testing.T) {
+	tests := []struct {
+		dir  units.Direction
+		u, v float64
+	}{
+		{0, 0, -10},
+		{90, -10, 0},
+		{270, 10, 0},
+		{225, 7.0710678, 7.0710678},
+	}
+
+	for _, test := range tests {
+		u, v := WindComponents(10, test.dir)
+
+		if math.Abs(float64(u)-test.u) > 1e-6 || math.Abs(float64(v)-test.v) > 1e-6 {
+			t.Errorf("Wind from %v split into %v %v", test.dir, u, v)
+		}
+	}
+}
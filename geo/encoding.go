@@ -0,0 +1,174 @@
+package geo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var wktPoint = regexp.MustCompile(`^(?i)POINT\s*(Z\s*)?\(\s*(\S+)\s+(\S+)(?:\s+(\S+))?\s*\)$`)
+
+type geoJSONGeometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// WKT returns the point as well-known text, with the elevation when it
+// is not zero.
+func (p Point) WKT() string {
+	if p.Elevation != 0 {
+		return fmt.Sprintf("POINT Z (%s %s %s)", formatFloat(p.Lon), formatFloat(p.Lat), formatFloat(p.Elevation))
+	}
+
+	return fmt.Sprintf("POINT (%s %s)", formatFloat(p.Lon), formatFloat(p.Lat))
+}
+
+// ParseWKT parses a POINT or POINT Z in well-known text.
+func ParseWKT(s string) (Point, error) {
+	m := wktPoint.FindStringSubmatch(strings.TrimSpace(s))
+
+	if m == nil {
+		return Point{}, errors.New(fmt.Sprintf("Could not parse WKT point %q", s))
+	}
+
+	coords := []float64{}
+
+	for _, c := range m[2:] {
+		if c == "" {
+			continue
+		}
+
+		v, err := strconv.ParseFloat(c, 64)
+
+		if err != nil {
+			return Point{}, errors.New(fmt.Sprintf("Could not parse WKT point %q", s))
+		}
+
+		coords = append(coords, v)
+	}
+
+	return pointFromCoordinates(coords)
+}
+
+// WKT returns the box as a well-known text POLYGON.
+func (b BBox) WKT() string {
+	corners := make([]string, 0, 5)
+
+	for _, c := range b.ring() {
+		corners = append(corners, formatFloat(c[0])+" "+formatFloat(c[1]))
+	}
+
+	return "POLYGON ((" + strings.Join(corners, ", ") + "))"
+}
+
+// MarshalJSON encodes the point as a GeoJSON Point geometry.
+func (p Point) MarshalJSON() ([]byte, error) {
+	coords := []float64{p.Lon, p.Lat}
+
+	if p.Elevation != 0 {
+		coords = append(coords, p.Elevation)
+	}
+
+	return marshalGeometry("Point", coords)
+}
+
+func (p *Point) UnmarshalJSON(data []byte) error {
+	var coords []float64
+
+	if err := unmarshalGeometry(data, "Point", &coords); err != nil {
+		return err
+	}
+
+	point, err := pointFromCoordinates(coords)
+
+	if err != nil {
+		return err
+	}
+
+	*p = point
+	return nil
+}
+
+// MarshalJSON encodes the box as a GeoJSON Polygon geometry.
+func (b BBox) MarshalJSON() ([]byte, error) {
+	return marshalGeometry("Polygon", [][][2]float64{b.ring()})
+}
+
+// UnmarshalJSON decodes the bounding box of a GeoJSON Polygon.
+func (b *BBox) UnmarshalJSON(data []byte) error {
+	var rings [][][]float64
+
+	if err := unmarshalGeometry(data, "Polygon", &rings); err != nil {
+		return err
+	}
+
+	if len(rings) == 0 || len(rings[0]) == 0 {
+		return errors.New("GeoJSON Polygon has no coordinates")
+	}
+
+	points := make([]Point, len(rings[0]))
+
+	for i, c := range rings[0] {
+		p, err := pointFromCoordinates(c)
+
+		if err != nil {
+			return err
+		}
+
+		points[i] = p
+	}
+
+	*b = NewBBox(points...)
+	return nil
+}
+
+func (b BBox) ring() [][2]float64 {
+	return [][2]float64{{b.West, b.South}, {b.East, b.South}, {b.East, b.North}, {b.West, b.North}, {b.West, b.South}}
+}
+
+func marshalGeometry(kind string, coords interface{}) ([]byte, error) {
+	data, err := json.Marshal(coords)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(geoJSONGeometry{kind, data})
+}
+
+func unmarshalGeometry(data []byte, kind string, coords interface{}) error {
+	var g geoJSONGeometry
+
+	if err := json.Unmarshal(data, &g); err != nil {
+		return err
+	}
+
+	if g.Type != kind {
+		return errors.New(fmt.Sprintf("Expected a GeoJSON %s, not %q", kind, g.Type))
+	}
+
+	return json.Unmarshal(g.Coordinates, coords)
+}
+
+// pointFromCoordinates converts longitude, latitude and optional
+// elevation coordinates, as in WKT and GeoJSON, to a valid Point.
+func pointFromCoordinates(coords []float64) (Point, error) {
+	if len(coords) < 2 || len(coords) > 3 {
+		return Point{}, errors.New(fmt.Sprintf("Expected 2 or 3 coordinates, not %d", len(coords)))
+	}
+
+	p := Point{Lat: coords[1], Lon: coords[0]}
+
+	if len(coords) == 3 {
+		p.Elevation = coords[2]
+	}
+
+	return p, p.Validate()
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,66 @@
+package geo
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestWKT(t *testing.T) {
+	tests := []struct {
+		s string
+		p Point
+	}{
+		{"POINT (-106.37 39.64)", Point{Lat: 39.64, Lon: -106.37}},
+		{"point(-106.37 39.64)", Point{Lat: 39.64, Lon: -106.37}},
+		{"POINT Z (-106.37 39.64 2475)", Point{Lat: 39.64, Lon: -106.37, Elevation: 2475}},
+	}
+
+	for _, test := range tests {
+		p, err := ParseWKT(test.s)
+
+		if err != nil || p != test.p {
+			t.Errorf("%q parsed as %+v %v", test.s, p, err)
+		}
+
+		if again, _ := ParseWKT(p.WKT()); again != p {
+			t.Errorf("%+v round tripped as %+v", p, again)
+		}
+	}
+
+	for _, s := range []string{"POINT (1)", "POINT (0 91)", "LINESTRING (0 0, 1 1)", "POINT (a b)"} {
+		if _, err := ParseWKT(s); err == nil {
+			t.Errorf("%q should not parse", s)
+		}
+	}
+
+	if wkt := (BBox{39, -106, 40, -104.5}).WKT(); wkt != "POLYGON ((-106 39, -104.5 39, -104.5 40, -106 40, -106 39))" {
+		t.Errorf("Box as %s", wkt)
+	}
+}
+
+func TestGeoJSON(t *testing.T) {
+	p := Point{Lat: 39.64, Lon: -106.37, Elevation: 2475}
+	data, err := json.Marshal(p)
+
+	if err != nil || string(data) != `{"type":"Point","coordinates":[-106.37,39.64,2475]}` {
+		t.Errorf("Marshaled as %s %v", data, err)
+	}
+
+	var q Point
+
+	if err := json.Unmarshal(data, &q); err != nil || q != p {
+		t.Errorf("Unmarshaled as %+v %v", q, err)
+	}
+
+	box := BBox{39, -106, 40, -104.5}
+	data, _ = json.Marshal(box)
+	var b BBox
+
+	if err := json.Unmarshal(data, &b); err != nil || b != box {
+		t.Errorf("Box round tripped through %s as %+v %v", data, b, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"type":"LineString","coordinates":[[0,0],[1,1]]}`), &q); err == nil {
+		t.Errorf("Unmarshaled a LineString as a Point")
+	}
+}
@@ -0,0 +1,191 @@
+package geo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// EarthRadius is the mean radius of the earth in meters, used for
+// haversine distances.
+const EarthRadius = 6371008.8
+
+// The WGS 84 ellipsoid used for Vincenty distances.
+const (
+	wgs84A = 6378137.0
+	wgs84F = 1 / 298.257223563
+	wgs84B = wgs84A * (1 - wgs84F)
+)
+
+// Point is a location in decimal degrees, with its elevation in meters
+// when it is known.
+type Point struct {
+	Lat       float64
+	Lon       float64
+	Elevation float64
+}
+
+// BBox is the box between the South and North latitudes and the West and
+// East longitudes.  Boxes may not cross the antimeridian.
+type BBox struct {
+	South float64
+	West  float64
+	North float64
+	East  float64
+}
+
+// NewPoint returns the point at lat, lon, or an error when either is out
+// of range.
+func NewPoint(lat, lon float64) (Point, error) {
+	p := Point{Lat: lat, Lon: lon}
+	return p, p.Validate()
+}
+
+func (p Point) Validate() error {
+	if math.IsNaN(p.Lat) || p.Lat < -90 || p.Lat > 90 {
+		return errors.New(fmt.Sprintf("Latitude %v is out of range", p.Lat))
+	}
+
+	if math.IsNaN(p.Lon) || p.Lon < -180 || p.Lon > 180 {
+		return errors.New(fmt.Sprintf("Longitude %v is out of range", p.Lon))
+	}
+
+	return nil
+}
+
+func (p Point) String() string {
+	return fmt.Sprintf("%.4f,%.4f", p.Lat, p.Lon)
+}
+
+func rad(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func deg(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
+
+// DistanceTo returns the great circle distance to q in meters, by the
+// haversine formula.
+func (p Point) DistanceTo(q Point) float64 {
+	dLat := rad(q.Lat - p.Lat)
+	dLon := rad(q.Lon - p.Lon)
+	a := math.Pow(math.Sin(dLat/2), 2) + math.Cos(rad(p.Lat))*math.Cos(rad(q.Lat))*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * EarthRadius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// VincentyDistanceTo returns the distance to q in meters on the WGS 84
+// ellipsoid.  It returns an error for nearly antipodal points, where
+// Vincenty's formula does not converge.
+func (p Point) VincentyDistanceTo(q Point) (float64, error) {
+	u1 := math.Atan((1 - wgs84F) * math.Tan(rad(p.Lat)))
+	u2 := math.Atan((1 - wgs84F) * math.Tan(rad(q.Lat)))
+	sinU1, cosU1 := math.Sincos(u1)
+	sinU2, cosU2 := math.Sincos(u2)
+	l := rad(q.Lon - p.Lon)
+	lambda := l
+
+	for i := 0; i < 200; i++ {
+		sinLambda, cosLambda := math.Sincos(lambda)
+		sinSigma := math.Hypot(cosU2*sinLambda, cosU1*sinU2-sinU1*cosU2*cosLambda)
+
+		if sinSigma == 0 {
+			return 0, nil
+		}
+
+		cosSigma := sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma := math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cos2Alpha := 1 - sinAlpha*sinAlpha
+		cos2SigmaM := 0.0
+
+		if cos2Alpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cos2Alpha
+		}
+
+		c := wgs84F / 16 * cos2Alpha * (4 + wgs84F*(4-3*cos2Alpha))
+		previous := lambda
+		lambda = l + (1-c)*wgs84F*sinAlpha*(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+
+		if math.Abs(lambda-previous) < 1e-12 {
+			u := cos2Alpha * (wgs84A*wgs84A - wgs84B*wgs84B) / (wgs84B * wgs84B)
+			a := 1 + u/16384*(4096+u*(-768+u*(320-175*u)))
+			b := u / 1024 * (256 + u*(-128+u*(74-47*u)))
+			deltaSigma := b * sinSigma * (cos2SigmaM + b/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+				b/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+			return wgs84B * a * (sigma - deltaSigma), nil
+		}
+	}
+
+	return 0, errors.New(fmt.Sprintf("Vincenty distance from %s to %s did not converge", p, q))
+}
+
+// BearingTo returns the initial bearing of the great circle to q in
+// degrees clockwise from north.
+func (p Point) BearingTo(q Point) float64 {
+	dLon := rad(q.Lon - p.Lon)
+	y := math.Sin(dLon) * math.Cos(rad(q.Lat))
+	x := math.Cos(rad(p.Lat))*math.Sin(rad(q.Lat)) - math.Sin(rad(p.Lat))*math.Cos(rad(q.Lat))*math.Cos(dLon)
+	return math.Mod(deg(math.Atan2(y, x))+360, 360)
+}
+
+// Destination returns the point distance meters from p along the great
+// circle with the initial bearing.
+func (p Point) Destination(bearing, distance float64) Point {
+	delta := distance / EarthRadius
+	lat1, lon1, theta := rad(p.Lat), rad(p.Lon), rad(bearing)
+	lat2 := math.Asin(math.Sin(lat1)*math.Cos(delta) + math.Cos(lat1)*math.Sin(delta)*math.Cos(theta))
+	lon2 := lon1 + math.Atan2(math.Sin(theta)*math.Sin(delta)*math.Cos(lat1), math.Cos(delta)-math.Sin(lat1)*math.Sin(lat2))
+	return Point{Lat: deg(lat2), Lon: math.Mod(deg(lon2)+540, 360) - 180}
+}
+
+// NewBBox returns the smallest box containing points.
+func NewBBox(points ...Point) BBox {
+	if len(points) == 0 {
+		return BBox{}
+	}
+
+	box := BBox{points[0].Lat, points[0].Lon, points[0].Lat, points[0].Lon}
+
+	for _, p := range points[1:] {
+		box = box.Extend(p)
+	}
+
+	return box
+}
+
+// Around returns the box containing every point within radius meters of
+// p, clamped to the poles.
+func Around(p Point, radius float64) BBox {
+	dLat := deg(radius / EarthRadius)
+	box := BBox{math.Max(-90, p.Lat-dLat), -180, math.Min(90, p.Lat+dLat), 180}
+
+	if box.South > -90 && box.North < 90 {
+		dLon := deg(math.Asin(math.Min(1, math.Sin(radius/EarthRadius)/math.Cos(rad(p.Lat)))))
+		box.West = math.Max(-180, p.Lon-dLon)
+		box.East = math.Min(180, p.Lon+dLon)
+	}
+
+	return box
+}
+
+func (b BBox) Contains(p Point) bool {
+	return p.Lat >= b.South && p.Lat <= b.North && p.Lon >= b.West && p.Lon <= b.East
+}
+
+// Extend returns the smallest box containing both b and p.
+func (b BBox) Extend(p Point) BBox {
+	return BBox{math.Min(b.South, p.Lat), math.Min(b.West, p.Lon), math.Max(b.North, p.Lat), math.Max(b.East, p.Lon)}
+}
+
+func (b BBox) Center() Point {
+	return Point{Lat: (b.South + b.North) / 2, Lon: (b.West + b.East) / 2}
+}
+
+func (b BBox) SouthWest() Point {
+	return Point{Lat: b.South, Lon: b.West}
+}
+
+func (b BBox) NorthEast() Point {
+	return Point{Lat: b.North, Lon: b.East}
+}
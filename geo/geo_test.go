@@ -0,0 +1,91 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewPoint(t *testing.T) {
+	for _, c := range [][2]float64{{91, 0}, {-90.5, 0}, {0, 180.1}, {math.NaN(), 0}} {
+		if _, err := NewPoint(c[0], c[1]); err == nil {
+			t.Errorf("%v should be out of range", c)
+		}
+	}
+
+	if p, err := NewPoint(-90, 180); err != nil || p.Lat != -90 {
+		t.Errorf("%+v %v", p, err)
+	}
+}
+
+func TestDistance(t *testing.T) {
+	lax := Point{Lat: 33.9425, Lon: -118.408056}
+	jfk := Point{Lat: 40.639722, Lon: -73.778889}
+
+	if d := lax.DistanceTo(jfk); math.Abs(d-3974200) > 1000 {
+		t.Errorf("Haversine distance LAX to JFK %v", d)
+	}
+
+	d, err := lax.VincentyDistanceTo(jfk)
+
+	if err != nil || math.Abs(d-3983000) > 2000 || math.Abs(d-lax.DistanceTo(jfk)) < 1 {
+		t.Errorf("Vincenty distance LAX to JFK %v %v", d, err)
+	}
+
+	flinders := Point{Lat: -(37 + 57/60.0 + 3.72030/3600), Lon: 144 + 25/60.0 + 29.52440/3600}
+	buninyong := Point{Lat: -(37 + 39/60.0 + 10.15610/3600), Lon: 143 + 55/60.0 + 35.38390/3600}
+
+	if d, err := flinders.VincentyDistanceTo(buninyong); err != nil || math.Abs(d-54972.271) > 0.001 {
+		t.Errorf("Vincenty distance Flinders Peak to Buninyong %v %v", d, err)
+	}
+
+	if d, err := lax.VincentyDistanceTo(lax); err != nil || d != 0 {
+		t.Errorf("Distance to self %v %v", d, err)
+	}
+
+	if _, err := (Point{Lat: 0, Lon: 0}).VincentyDistanceTo(Point{Lat: 0.5, Lon: 179.7}); err == nil {
+		t.Errorf("Nearly antipodal points should not converge")
+	}
+}
+
+func TestBearingAndDestination(t *testing.T) {
+	p := Point{Lat: 40, Lon: -105}
+
+	if b := p.BearingTo(Point{Lat: 41, Lon: -105}); math.Abs(b) > 1e-9 {
+		t.Errorf("Bearing north %v", b)
+	}
+
+	if b := p.BearingTo(Point{Lat: 40, Lon: -106}); b < 270 || b > 271 {
+		t.Errorf("Bearing west %v", b)
+	}
+
+	q := p.Destination(60, 100000)
+
+	if math.Abs(p.DistanceTo(q)-100000) > 1e-6 || math.Abs(p.BearingTo(q)-60) > 1e-9 {
+		t.Errorf("Destination %+v", q)
+	}
+}
+
+func TestBBox(t *testing.T) {
+	box := NewBBox(Point{Lat: 40, Lon: -104}, Point{Lat: 39, Lon: -106}, Point{Lat: 39.5, Lon: -105})
+
+	if box != (BBox{39, -106, 40, -104}) || box.Center() != (Point{Lat: 39.5, Lon: -105}) {
+		t.Errorf("Bounding box %+v", box)
+	}
+
+	if !box.Contains(Point{Lat: 39.64, Lon: -105.5}) || box.Contains(Point{Lat: 40.1, Lon: -105}) {
+		t.Errorf("Contains incorrect")
+	}
+
+	center := Point{Lat: 45, Lon: 10}
+	around := Around(center, 50000)
+
+	for _, bearing := range []float64{0, 45, 90, 135, 180, 225, 270, 315} {
+		if p := center.Destination(bearing, 49999); !around.Contains(p) {
+			t.Errorf("%+v does not contain %+v", around, p)
+		}
+	}
+
+	if polar := Around(Point{Lat: 89.9, Lon: 0}, 50000); polar.North != 90 || polar.West != -180 {
+		t.Errorf("Polar box %+v", polar)
+	}
+}
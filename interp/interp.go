@@ -0,0 +1,150 @@
+package interp
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gershwinlabs/noaa/geo"
+	"github.com/gershwinlabs/noaa/units"
+	"math"
+	"sort"
+)
+
+// Observation is a value observed at a station.
+type Observation struct {
+	Station string
+	Point   geo.Point
+	Value   float64
+}
+
+// Contribution is a station used in an Estimate, with its distance from
+// the estimated point in meters, its value after any lapse rate
+// adjustment, and its share of the estimate.
+type Contribution struct {
+	Station  string
+	Distance float64
+	Value    float64
+	Weight   float64
+}
+
+// Estimate is an interpolated value and the stations that contributed
+// to it, nearest first.  The weights of the contributions sum to 1.
+type Estimate struct {
+	Point         geo.Point
+	Value         float64
+	Contributions []Contribution
+}
+
+// Options control which stations are used and how their values are
+// adjusted.  Stations further than MaxDistance meters away are ignored,
+// and only the MaxStations nearest are used; zero means no limit.
+// Power is the exponent of inverse distance weighting, 2 when zero.
+// Each station's value is adjusted by LapseRate per meter of elevation
+// between it and the estimated point.
+type Options struct {
+	Power       float64
+	MaxDistance float64
+	MaxStations int
+	LapseRate   float64
+}
+
+// Method estimates the value at target from observations.
+type Method func(target geo.Point, obs []Observation, opts Options) (Estimate, error)
+
+// StandardLapseRate returns the standard atmosphere's fall in
+// temperature with height, 6.5°C per kilometer, per meter in the
+// temperature unit u.
+func StandardLapseRate(u units.Unit) float64 {
+	if u == units.Fahrenheit {
+		return -0.0065 * 9 / 5
+	}
+
+	return -0.0065
+}
+
+// IDW estimates the value at target by inverse distance weighting.  An
+// observation at the target itself is used alone.
+func IDW(target geo.Point, obs []Observation, opts Options) (Estimate, error) {
+	contributions, err := nearest(target, obs, opts)
+
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	power := opts.Power
+
+	if power == 0 {
+		power = 2
+	}
+
+	if contributions[0].Distance == 0 {
+		return single(target, contributions[0]), nil
+	}
+
+	total := 0.0
+
+	for i, c := range contributions {
+		contributions[i].Weight = 1 / math.Pow(c.Distance, power)
+		total += contributions[i].Weight
+	}
+
+	estimate := Estimate{Point: target, Contributions: contributions}
+
+	for i, c := range contributions {
+		contributions[i].Weight = c.Weight / total
+		estimate.Value += c.Value * contributions[i].Weight
+	}
+
+	return estimate, nil
+}
+
+// Nearest estimates the value at target as the value of the nearest
+// station.
+func Nearest(target geo.Point, obs []Observation, opts Options) (Estimate, error) {
+	contributions, err := nearest(target, obs, opts)
+
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	return single(target, contributions[0]), nil
+}
+
+func single(target geo.Point, c Contribution) Estimate {
+	c.Weight = 1
+	return Estimate{target, c.Value, []Contribution{c}}
+}
+
+// nearest returns the stations selected by opts, nearest first, with
+// their lapse rate adjusted values.
+func nearest(target geo.Point, obs []Observation, opts Options) ([]Contribution, error) {
+	if err := target.Validate(); err != nil {
+		return []Contribution{}, err
+	}
+
+	contributions := make([]Contribution, 0, len(obs))
+
+	for _, o := range obs {
+		d := target.DistanceTo(o.Point)
+
+		if opts.MaxDistance > 0 && d > opts.MaxDistance {
+			continue
+		}
+
+		v := o.Value + opts.LapseRate*(target.Elevation-o.Point.Elevation)
+		contributions = append(contributions, Contribution{o.Station, d, v, 0})
+	}
+
+	if len(contributions) == 0 {
+		return contributions, errors.New(fmt.Sprintf("No observations near %s", target))
+	}
+
+	sort.SliceStable(contributions, func(i, j int) bool {
+		return contributions[i].Distance < contributions[j].Distance
+	})
+
+	if opts.MaxStations > 0 && len(contributions) > opts.MaxStations {
+		contributions = contributions[:opts.MaxStations]
+	}
+
+	return contributions, nil
+}
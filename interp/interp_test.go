@@ -0,0 +1,82 @@
+package interp
+
+import (
+	"github.com/gershwinlabs/noaa/geo"
+	"github.com/gershwinlabs/noaa/units"
+	"math"
+	"testing"
+)
+
+var stations = []Observation{
+	{"A", geo.Point{Lat: 40, Lon: -105, Elevation: 1600}, 10},
+	{"B", geo.Point{Lat: 40, Lon: -104, Elevation: 1600}, 20},
+	{"C", geo.Point{Lat: 41, Lon: -105, Elevation: 2600}, 4},
+}
+
+func TestIDW(t *testing.T) {
+	midway := geo.Point{Lat: 40, Lon: -104.5}
+	e, err := IDW(midway, stations[:2], Options{})
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if math.Abs(e.Value-15) > 1e-6 || len(e.Contributions) != 2 || math.Abs(e.Contributions[0].Weight-0.5) > 1e-6 {
+		t.Errorf("Midway estimate %+v", e)
+	}
+
+	near := geo.Point{Lat: 40, Lon: -104.9}
+	e, _ = IDW(near, stations, Options{})
+	total := 0.0
+
+	for _, c := range e.Contributions {
+		total += c.Weight
+	}
+
+	if e.Contributions[0].Station != "A" || e.Contributions[0].Weight < 0.9 || math.Abs(total-1) > 1e-9 || e.Value < 10 || e.Value > 11 {
+		t.Errorf("Estimate near A %+v", e)
+	}
+
+	if e, _ := IDW(near, stations, Options{MaxStations: 2}); len(e.Contributions) != 2 || e.Contributions[1].Station != "B" {
+		t.Errorf("Estimate from two stations %+v", e)
+	}
+
+	if e, _ := IDW(stations[1].Point, stations, Options{}); e.Value != 20 || len(e.Contributions) != 1 || e.Contributions[0].Weight != 1 {
+		t.Errorf("Estimate at B %+v", e)
+	}
+
+	if _, err := IDW(near, stations, Options{MaxDistance: 1000}); err == nil {
+		t.Errorf("Estimated without stations in range")
+	}
+
+	if _, err := IDW(geo.Point{Lat: 100}, stations, Options{}); err == nil {
+		t.Errorf("Estimated beyond the pole")
+	}
+}
+
+func TestNearest(t *testing.T) {
+	e, err := Nearest(geo.Point{Lat: 40.9, Lon: -105}, stations, Options{})
+
+	if err != nil || e.Value != 4 || e.Contributions[0].Station != "C" || e.Contributions[0].Weight != 1 {
+		t.Errorf("Nearest estimate %+v %v", e, err)
+	}
+}
+
+func TestLapseRate(t *testing.T) {
+	target := geo.Point{Lat: 40.5, Lon: -105, Elevation: 2100}
+	e, err := Nearest(target, stations, Options{LapseRate: StandardLapseRate(units.Celsius)})
+
+	if err != nil || math.Abs(e.Value-(10-3.25)) > 1e-9 {
+		t.Errorf("Lapse adjusted estimate %+v %v", e, err)
+	}
+
+	e, _ = IDW(target, []Observation{stations[0], stations[2]}, Options{LapseRate: StandardLapseRate(units.Celsius)})
+
+	if math.Abs(e.Contributions[1].Value-(4+3.25)) > 1e-9 {
+		t.Errorf("Lapse adjusted contributions %+v", e.Contributions)
+	}
+
+	if StandardLapseRate(units.Fahrenheit) != -0.0117 {
+		t.Errorf("Fahrenheit lapse rate %v", StandardLapseRate(units.Fahrenheit))
+	}
+}
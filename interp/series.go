@@ -0,0 +1,84 @@
+package interp
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gershwinlabs/noaa"
+	"github.com/gershwinlabs/noaa/geo"
+	"sort"
+)
+
+// Series estimates a series at target from series of one variable at
+// other locations, such as the GHCND series of nearby stations, for each
+// valid span in any of them.  Locations are looked up in points by ID,
+// so that station elevations can be used, and otherwise placed at their
+// coordinates.  Values are converted to the unit of the first series,
+// and spans with no usable observations are left out.  The Estimate
+// behind each sample is returned alongside it.
+func Series(target geo.Point, series []noaa.Series, points map[string]geo.Point, method Method, opts Options) (noaa.Series, []Estimate, error) {
+	if err := target.Validate(); err != nil {
+		return noaa.Series{}, []Estimate{}, err
+	}
+
+	if len(series) == 0 {
+		return noaa.Series{}, []Estimate{}, errors.New("No series to interpolate")
+	}
+
+	first := series[0]
+	result := noaa.Series{
+		Variable:   first.Variable,
+		Location:   noaa.Location{Lat: target.Lat, Lon: target.Lon},
+		Unit:       first.Unit,
+		Provenance: first.Provenance,
+		Samples:    []noaa.Sample{},
+	}
+	estimates := make([]Estimate, 0)
+
+	spans := make([]noaa.TimeSpan, 0)
+	obs := make(map[noaa.TimeSpan][]Observation)
+
+	for _, s := range series {
+		if s.Variable != first.Variable {
+			return result, estimates, errors.New(fmt.Sprintf("Cannot interpolate series of %s with %s", s.Variable, first.Variable))
+		}
+
+		converted, err := s.ConvertTo(first.Unit)
+
+		if err != nil {
+			return result, estimates, err
+		}
+
+		p, ok := points[s.Location.ID]
+
+		if !ok {
+			p = geo.Point{Lat: s.Location.Lat, Lon: s.Location.Lon}
+		}
+
+		for _, sample := range converted.Samples {
+			key := noaa.TimeSpan{Begin: sample.Valid.Begin.UTC(), End: sample.Valid.End.UTC()}
+
+			if _, ok := obs[key]; !ok {
+				spans = append(spans, key)
+			}
+
+			obs[key] = append(obs[key], Observation{s.Location.ID, p, sample.Value})
+		}
+	}
+
+	sort.SliceStable(spans, func(i, j int) bool {
+		return spans[i].Begin.Before(spans[j].Begin)
+	})
+
+	for _, span := range spans {
+		estimate, err := method(target, obs[span], opts)
+
+		if err != nil {
+			continue
+		}
+
+		result.Samples = append(result.Samples, noaa.Sample{Valid: span, Value: estimate.Value})
+		estimates = append(estimates, estimate)
+	}
+
+	return result, estimates, nil
+}
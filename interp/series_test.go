@@ -0,0 +1,66 @@
+package interp
+
+import (
+	"github.com/gershwinlabs/noaa"
+	"github.com/gershwinlabs/noaa/geo"
+	"github.com/gershwinlabs/noaa/units"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSeries(t *testing.T) {
+	day := time.Date(2016, 3, 1, 0, 0, 0, 0, time.UTC)
+	span := func(d int) noaa.TimeSpan {
+		return noaa.TimeSpan{Begin: day.AddDate(0, 0, d), End: day.AddDate(0, 0, d+1)}
+	}
+
+	a := noaa.Series{
+		Variable: noaa.MaxTemperature,
+		Location: noaa.Location{ID: "A", Lat: 40, Lon: -105},
+		Unit:     units.Celsius,
+		Samples:  []noaa.Sample{{Valid: span(0), Value: 10}, {Valid: span(1), Value: 12}},
+	}
+	b := noaa.Series{
+		Variable: noaa.MaxTemperature,
+		Location: noaa.Location{ID: "B", Lat: 40, Lon: -104},
+		Unit:     units.Fahrenheit,
+		Samples:  []noaa.Sample{{Valid: span(1), Value: 68}, {Valid: span(0), Value: 68}},
+	}
+
+	points := map[string]geo.Point{"B": {Lat: 40, Lon: -104, Elevation: 1000}}
+	s, estimates, err := Series(geo.Point{Lat: 40, Lon: -104.5}, []noaa.Series{a, b}, points, IDW, Options{LapseRate: StandardLapseRate(units.Celsius)})
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if len(s.Samples) != 2 || s.Unit != units.Celsius || s.Location.Lon != -104.5 || !s.Samples[0].Valid.Begin.Equal(day) {
+		t.Fatalf("Interpolated series %+v", s)
+	}
+
+	if math.Abs(s.Samples[0].Value-18.25) > 1e-6 || math.Abs(s.Samples[1].Value-19.25) > 1e-6 {
+		t.Errorf("Interpolated samples %+v", s.Samples)
+	}
+
+	if len(estimates) != 2 || len(estimates[1].Contributions) != 2 || estimates[1].Value != s.Samples[1].Value {
+		t.Errorf("Estimates %+v", estimates)
+	}
+
+	for _, e := range estimates {
+		if e.Contributions[0].Weight != 0.5 {
+			t.Errorf("Estimate %+v should weight both stations equally", e)
+		}
+	}
+
+	c := a
+	c.Variable = noaa.Precipitation
+
+	if _, _, err := Series(geo.Point{Lat: 40, Lon: -104.5}, []noaa.Series{a, c}, nil, Nearest, Options{}); err == nil {
+		t.Errorf("Interpolated temperatures with precipitation")
+	}
+
+	if _, _, err := Series(geo.Point{Lat: 91, Lon: -104.5}, []noaa.Series{a, b}, nil, IDW, Options{}); err == nil {
+		t.Errorf("Interpolated series at an invalid target")
+	}
+}
@@ -0,0 +1,198 @@
+package noaa
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// now is replaced in tests so relative spans are predictable.
+var now = time.Now
+
+var (
+	isoDuration = regexp.MustCompile(`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+	relative    = regexp.MustCompile(`^(last|past|next)\s+(?:(\d+)\s*(min|h|d|w|mo|y)|(p\S+))$`)
+)
+
+var relativeUnits = map[string]Step{
+	"min": Minute,
+	"h":   Hour,
+	"d":   Day,
+	"w":   Week,
+	"mo":  Month,
+	"y":   {Months: 12},
+}
+
+// ParseTimeSpan parses an ISO 8601 time interval, as start/end,
+// start/duration or duration/end, or a span relative to the current time
+// such as "last 30d", "next 7d" or "last P2W".  Relative spans accept
+// the units min, h, d, w, mo and y.  Times are RFC 3339 timestamps or
+// dates, which are taken to be midnight UTC.
+func ParseTimeSpan(s string) (TimeSpan, error) {
+	s = strings.TrimSpace(s)
+
+	if m := relative.FindStringSubmatch(strings.ToLower(s)); m != nil {
+		return parseRelative(m, s)
+	}
+
+	parts := strings.Split(s, "/")
+
+	if len(parts) != 2 {
+		return TimeSpan{}, errors.New(fmt.Sprintf("Could not parse time span %q", s))
+	}
+
+	switch {
+	case strings.HasPrefix(parts[0], "P") && strings.HasPrefix(parts[1], "P"):
+		return TimeSpan{}, errors.New(fmt.Sprintf("Time span %q has no start or end", s))
+	case strings.HasPrefix(parts[1], "P"):
+		begin, err := parseISOTime(parts[0])
+
+		if err != nil {
+			return TimeSpan{}, err
+		}
+
+		step, err := ParseISODuration(parts[1])
+
+		if err != nil {
+			return TimeSpan{}, err
+		}
+
+		return TimeSpan{Begin: begin, End: step.advance(begin, 1)}, nil
+	case strings.HasPrefix(parts[0], "P"):
+		step, err := ParseISODuration(parts[0])
+
+		if err != nil {
+			return TimeSpan{}, err
+		}
+
+		end, err := parseISOTime(parts[1])
+
+		if err != nil {
+			return TimeSpan{}, err
+		}
+
+		return TimeSpan{Begin: step.advance(end, -1), End: end}, nil
+	}
+
+	begin, err := parseISOTime(parts[0])
+
+	if err != nil {
+		return TimeSpan{}, err
+	}
+
+	end, err := parseISOTime(parts[1])
+
+	if err != nil {
+		return TimeSpan{}, err
+	}
+
+	return TimeSpan{Begin: begin, End: end}, nil
+}
+
+// ParseISODuration parses an ISO 8601 duration such as P1Y2M10DT2H30M.
+// Years, months, weeks and days become calendar steps, and the time
+// components become a Duration.
+func ParseISODuration(s string) (Step, error) {
+	u := strings.ToUpper(strings.TrimSpace(s))
+	m := isoDuration.FindStringSubmatch(u)
+
+	if m == nil || u == "P" || strings.HasSuffix(u, "T") {
+		return Step{}, errors.New(fmt.Sprintf("Could not parse duration %q", s))
+	}
+
+	n := func(i int) int {
+		v, _ := strconv.Atoi(m[i])
+		return v
+	}
+
+	seconds, _ := strconv.ParseFloat(m[7], 64)
+	step := Step{
+		Months:   n(1)*12 + n(2),
+		Days:     n(3)*7 + n(4),
+		Duration: time.Duration(n(5))*time.Hour + time.Duration(n(6))*time.Minute + time.Duration(seconds*float64(time.Second)),
+	}
+
+	return step, nil
+}
+
+func parseRelative(m []string, s string) (TimeSpan, error) {
+	var step Step
+
+	if m[4] != "" {
+		var err error
+		step, err = ParseISODuration(m[4])
+
+		if err != nil {
+			return TimeSpan{}, err
+		}
+	} else {
+		count, err := strconv.Atoi(m[2])
+
+		if err != nil {
+			return TimeSpan{}, errors.New(fmt.Sprintf("Could not parse time span %q", s))
+		}
+
+		unit := relativeUnits[m[3]]
+		step = Step{unit.Months * count, unit.Days * count, unit.Duration * time.Duration(count)}
+	}
+
+	t := now()
+
+	if m[1] == "next" {
+		return TimeSpan{Begin: t, End: step.advance(t, 1)}, nil
+	}
+
+	return TimeSpan{Begin: step.advance(t, -1), End: t}, nil
+}
+
+func parseISOTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse("2006-01-02", s)
+
+	if err != nil {
+		return t, errors.New(fmt.Sprintf("Could not parse time %q", s))
+	}
+
+	return t, nil
+}
+
+// String formats the span as an ISO 8601 start/end interval.
+func (ts TimeSpan) String() string {
+	return ts.Begin.Format(time.RFC3339Nano) + "/" + ts.End.Format(time.RFC3339Nano)
+}
+
+func (ts TimeSpan) MarshalText() ([]byte, error) {
+	return []byte(ts.String()), nil
+}
+
+func (ts *TimeSpan) UnmarshalText(text []byte) error {
+	parsed, err := ParseTimeSpan(string(text))
+
+	if err != nil {
+		return err
+	}
+
+	*ts = parsed
+	return nil
+}
+
+func (ts TimeSpan) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ts.String())
+}
+
+func (ts *TimeSpan) UnmarshalJSON(data []byte) error {
+	var s string
+
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	return ts.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,81 @@
+package noaa
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseTimeSpan(t *testing.T) {
+	fixed := time.Date(2016, 3, 1, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return fixed }
+	defer func() { now = time.Now }()
+
+	tests := []struct {
+		s     string
+		begin time.Time
+		end   time.Time
+	}{
+		{"2016-03-01T00:00:00Z/2016-03-02T06:00:00Z", fixed.Add(-12 * time.Hour), fixed.Add(18 * time.Hour)},
+		{"2016-03-01/2016-03-02", fixed.Add(-12 * time.Hour), fixed.Add(12 * time.Hour)},
+		{"2016-01-31T00:00:00Z/P1M", time.Date(2016, 1, 31, 0, 0, 0, 0, time.UTC), time.Date(2016, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{"2016-03-01T12:00:00Z/PT36H", fixed, fixed.Add(36 * time.Hour)},
+		{"P1DT12H/2016-03-01T12:00:00Z", fixed.Add(-36 * time.Hour), fixed},
+		{"P2W/2016-03-01T12:00:00Z", fixed.AddDate(0, 0, -14), fixed},
+		{"last 30d", fixed.AddDate(0, 0, -30), fixed},
+		{"next 7d", fixed, fixed.AddDate(0, 0, 7)},
+		{"Past 90 min", fixed.Add(-90 * time.Minute), fixed},
+		{"last P1Y", fixed.AddDate(-1, 0, 0), fixed},
+	}
+
+	for _, test := range tests {
+		ts, err := ParseTimeSpan(test.s)
+
+		if err != nil {
+			t.Errorf("%s", err)
+			continue
+		}
+
+		if !ts.Begin.Equal(test.begin) || !ts.End.Equal(test.end) {
+			t.Errorf("%s parsed as %s", test.s, ts)
+		}
+	}
+
+	for _, s := range []string{"", "2016-03-01", "P1D/P2D", "2016-03-01/P", "2016-03-01/PT", "last week", "2016-03-01/tomorrow"} {
+		if _, err := ParseTimeSpan(s); err == nil {
+			t.Errorf("%q should not parse", s)
+		}
+	}
+}
+
+func TestTimeSpanJSON(t *testing.T) {
+	type config struct {
+		Span TimeSpan `json:"span"`
+	}
+
+	begin := time.Date(2016, 3, 1, 17, 0, 0, 0, time.FixedZone("MST", -7*3600))
+	in := config{TimeSpan{Begin: begin, End: begin.Add(90 * time.Minute)}}
+	data, err := json.Marshal(in)
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if string(data) != `{"span":"2016-03-01T17:00:00-07:00/2016-03-01T18:30:00-07:00"}` {
+		t.Errorf("Marshaled as %s", data)
+	}
+
+	var out config
+
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if !out.Span.Begin.Equal(in.Span.Begin) || !out.Span.End.Equal(in.Span.End) {
+		t.Errorf("Unmarshaled as %s", out.Span)
+	}
+
+	if err := json.Unmarshal([]byte(`{"span":"next 3d"}`), &out); err != nil || out.Span.Duration() != 72*time.Hour {
+		t.Errorf("Relative span unmarshaled as %s %v", out.Span, err)
+	}
+}
@@ -0,0 +1,184 @@
+package ndfd
+
+import (
+	"fmt"
+	"github.com/gershwinlabs/noaa"
+	"github.com/gershwinlabs/noaa/units"
+	"math"
+	"net/http"
+	"time"
+)
+
+type ByDayFormat string
+
+const (
+	TwelveHourly     ByDayFormat = "12 hourly"
+	TwentyFourHourly ByDayFormat = "24 hourly"
+)
+
+// DayRequest is a request for the NDFDgenByDay summarized forecast,
+// starting on StartDate and running for NumDays days.
+type DayRequest struct {
+	Selection Selection
+	Format    ByDayFormat
+	StartDate time.Time
+	NumDays   int
+	Units     units.System
+}
+
+type NDFDByDay struct {
+	SourceURL string
+	Dwml      *DWML
+	Forecasts []DailyForecast
+}
+
+type DailyForecast struct {
+	Location string
+	Lat      float64
+	Lon      float64
+	Periods  []ForecastPeriod
+}
+
+// ForecastPeriod summarizes a 12 or 24 hour period.  Temperatures and
+// PoP that are not forecast for the period are NaN.
+type ForecastPeriod struct {
+	TimeSpan         noaa.TimeSpan
+	MaxTemperature   float64
+	MinTemperature   float64
+	TemperatureUnits string
+	PoP              float64
+	Weather          string
+	Icon             Icon
+}
+
+func (r DayRequest) URL() string {
+	q := r.Selection.query()
+	q.Set("whichClient", "NDFDgenByDay"+r.Selection.clientSuffix())
+	q.Set("format", string(r.Format))
+	q.Set("startDate", r.StartDate.Format("2006-01-02"))
+	q.Set("numDays", fmt.Sprintf("%d", r.NumDays))
+	q.Set("Unit", r.Units.Param())
+	return sourceURLBase + "?" + q.Encode()
+}
+
+func FetchNDFDByDay(lat, lon float64, format ByDayFormat) (NDFDByDay, error) {
+	return FetchNDFDByDayWithClient(http.DefaultClient, lat, lon, format)
+}
+
+func FetchNDFDByDayWithClient(client *http.Client, lat, lon float64, format ByDayFormat) (NDFDByDay, error) {
+	return FetchDayRequestWithClient(client, DayRequest{Point{lat, lon}, format, time.Now(), 7, units.Metric})
+}
+
+func FetchDayRequestWithClient(client *http.Client, r DayRequest) (NDFDByDay, error) {
+	sourceURL := r.URL()
+	resp, err := client.Get(sourceURL)
+
+	if err != nil {
+		return NDFDByDay{}, err
+	}
+
+	dwml, err := decodeDWMLResponse(resp, sourceURL)
+
+	if err != nil {
+		return NDFDByDay{}, err
+	}
+
+	forecasts, err := dwml.DailyForecasts()
+
+	if err != nil {
+		return NDFDByDay{}, err
+	}
+
+	return NDFDByDay{sourceURL, dwml, forecasts}, nil
+}
+
+// DailyForecasts builds a DailyForecast for every location, with one
+// period for each weather or icon period in the document.
+func (dwml *DWML) DailyForecasts() ([]DailyForecast, error) {
+	forecasts := make([]DailyForecast, 0, len(dwml.Data.Parameters))
+
+	for _, dp := range dwml.Data.Parameters {
+		loc, err := dwml.Data.applicableLocation(dp.ApplicableLocation)
+
+		if err != nil {
+			return forecasts, err
+		}
+
+		periods, err := dwml.forecastPeriods(dp)
+
+		if err != nil {
+			return forecasts, err
+		}
+
+		forecasts = append(forecasts, DailyForecast{loc.LocationKey, loc.Point.Latitude, loc.Point.Longitude, periods})
+	}
+
+	return forecasts, nil
+}
+
+func (dwml *DWML) forecastPeriods(dp DataParameters) ([]ForecastPeriod, error) {
+	layout, summaries, err := dp.WeatherSummaries()
+	iconLayout, icons, iconErr := dp.ConditionsIcons()
+
+	if err != nil {
+		if iconErr != nil {
+			return []ForecastPeriod{}, err
+		}
+
+		layout = iconLayout
+	}
+
+	spans, err := dwml.layoutTimeSpans(layout)
+
+	if err != nil {
+		return []ForecastPeriod{}, err
+	}
+
+	periods := make([]ForecastPeriod, len(spans))
+
+	for i, span := range spans {
+		periods[i] = ForecastPeriod{span, math.NaN(), math.NaN(), "", math.NaN(), "", Icon{}}
+
+		if i < len(summaries) {
+			periods[i].Weather = summaries[i]
+		}
+
+		if iconErr == nil && iconLayout == layout && i < len(icons) {
+			periods[i].Icon = icons[i]
+		}
+	}
+
+	maxt, _ := dwml.parameterPeriods(dp, DataParameters.DailyMaximumTemperatures)
+	mint, _ := dwml.parameterPeriods(dp, DataParameters.DailyMinimumTemperatures)
+	pops, _ := dwml.parameterPeriods(dp, DataParameters.TwelveHourPoPs)
+
+	for i := range periods {
+		span := periods[i].TimeSpan
+
+		for _, p := range maxt {
+			if beginsWithin(p.TimeSpan, span) {
+				periods[i].MaxTemperature = p.Value
+				periods[i].TemperatureUnits = p.Units
+			}
+		}
+
+		for _, p := range mint {
+			if beginsWithin(p.TimeSpan, span) {
+				periods[i].MinTemperature = p.Value
+				periods[i].TemperatureUnits = p.Units
+			}
+		}
+
+		for _, p := range pops {
+			if beginsWithin(p.TimeSpan, span) && (math.IsNaN(periods[i].PoP) || p.Value > periods[i].PoP) {
+				periods[i].PoP = p.Value
+			}
+		}
+	}
+
+	return periods, nil
+}
+
+func beginsWithin(inner, outer noaa.TimeSpan) bool {
+	return !inner.Begin.Before(outer.Begin) && inner.Begin.Before(outer.End)
+}
@@ -0,0 +1,50 @@
+package ndfd
+
+import (
+	"github.com/gershwinlabs/noaa/units"
+	"math"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestDayRequestURL(t *testing.T) {
+	start := time.Date(2016, 3, 1, 0, 0, 0, 0, time.UTC)
+	u, err := url.Parse(DayRequest{ZipCodeList{"80424"}, TwelveHourly, start, 5, units.English}.URL())
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	q := u.Query()
+
+	if q.Get("whichClient") != "NDFDgenByDayMultiZipCode" || q.Get("format") != "12 hourly" || q.Get("startDate") != "2016-03-01" || q.Get("numDays") != "5" || q.Get("Unit") != "e" {
+		t.Errorf("Unexpected query %s", u.RawQuery)
+	}
+}
+
+func TestDailyForecasts(t *testing.T) {
+	dwml := loadDWML(t, "by-day.xml")
+
+	forecasts, err := dwml.DailyForecasts()
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if len(forecasts) != 1 || len(forecasts[0].Periods) != 2 {
+		t.Fatalf("Daily forecasts decoded as %+v", forecasts)
+	}
+
+	first := forecasts[0].Periods[0]
+
+	if first.MaxTemperature != 4 || first.MinTemperature != -9 || first.PoP != 40 || first.Weather != "Chance Snow Showers" || first.Icon.Code != "sn" {
+		t.Errorf("First period decoded as %+v", first)
+	}
+
+	second := forecasts[0].Periods[1]
+
+	if second.MaxTemperature != 7 || !math.IsNaN(second.MinTemperature) || second.PoP != 5 || second.Icon.Code != "skc" {
+		t.Errorf("Second period decoded as %+v", second)
+	}
+}
@@ -0,0 +1,222 @@
+package ndfd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gershwinlabs/noaa"
+	"math"
+	"time"
+)
+
+type ClimatePeriod string
+
+const (
+	Weekly   ClimatePeriod = "weekly"
+	Monthly  ClimatePeriod = "monthly"
+	Seasonal ClimatePeriod = "seasonal"
+)
+
+type ClimateAnomaly string
+
+const (
+	TemperatureAboveNormal   ClimateAnomaly = "average temperature above normal"
+	TemperatureBelowNormal   ClimateAnomaly = "average temperature below normal"
+	PrecipitationAboveNormal ClimateAnomaly = "total precipitation above normal"
+	PrecipitationBelowNormal ClimateAnomaly = "total precipitation below normal"
+)
+
+type OutlookWindow string
+
+const (
+	SixToTenDay     OutlookWindow = "6-10 day"
+	EightToFourteen OutlookWindow = "8-14 day"
+	OneMonth        OutlookWindow = "monthly"
+	ThreeMonth      OutlookWindow = "seasonal"
+)
+
+// ClimateOutlook holds the Climate Prediction Center probabilities of
+// temperature or precipitation falling above or below normal during an
+// outlook window.
+type ClimateOutlook struct {
+	Window      OutlookWindow
+	TimeSpan    noaa.TimeSpan
+	Variable    string
+	AboveNormal float64
+	BelowNormal float64
+}
+
+func (co ClimateOutlook) NearNormal() float64 {
+	return 100 - co.AboveNormal - co.BelowNormal
+}
+
+// ClimateAnomalySections returns each section of the anomaly for the
+// period, in the order they appear.  The 6-10 and 8-14 day outlooks are
+// separate weekly sections with their own time layouts.  The DWML schema
+// spells the element climate-anomaly, but this package used to decode
+// climate-anomoly, so sections under either spelling are returned.
+func (dp DataParameters) ClimateAnomalySections(period ClimatePeriod, anomaly ClimateAnomaly) []DataParametersSection {
+	sections := make([]DataParametersSection, 0)
+	anomalies := append(append([]DataParametersClimateAnomaly{}, dp.ClimateAnomalies...), dp.MisspelledClimateAnomalies...)
+
+	for _, ca := range anomalies {
+		var candidates []DataParametersSection
+
+		switch period {
+		case Weekly:
+			candidates = ca.Weekly
+		case Monthly:
+			candidates = ca.Monthly
+		case Seasonal:
+			candidates = ca.Seasonal
+		}
+
+		for _, section := range candidates {
+			if section.Type == string(anomaly) {
+				sections = append(sections, section)
+			}
+		}
+	}
+
+	return sections
+}
+
+// ClimateAnomalyProbabilities returns the probabilities of the anomaly
+// for the period.  It returns an error when they are split across
+// sections with different time layouts, which ClimateAnomalySections
+// keeps apart.
+func (dp DataParameters) ClimateAnomalyProbabilities(period ClimatePeriod, anomaly ClimateAnomaly) (string, string, []float64, error) {
+	sections := dp.ClimateAnomalySections(period, anomaly)
+
+	for _, section := range sections[min(1, len(sections)):] {
+		if section.TimeLayout != sections[0].TimeLayout {
+			return section.TimeLayout, section.Units, []float64{}, errors.New(fmt.Sprintf("Climate anomaly %s has time layouts %s and %s", anomaly, sections[0].TimeLayout, section.TimeLayout))
+		}
+	}
+
+	return GetParametersSection(sections, string(anomaly))
+}
+
+func (dwml *DWML) ClimateOutlooks() ([]ClimateOutlook, error) {
+	dp, err := dwml.Data.singleParameters()
+
+	if err != nil {
+		return []ClimateOutlook{}, err
+	}
+
+	outlooks := make([]ClimateOutlook, 0, 8)
+
+	for _, period := range []ClimatePeriod{Weekly, Monthly, Seasonal} {
+		temps, err := dwml.climateOutlooks(dp, period, "temperature", TemperatureAboveNormal, TemperatureBelowNormal)
+
+		if err != nil {
+			return outlooks, err
+		}
+
+		precips, err := dwml.climateOutlooks(dp, period, "precipitation", PrecipitationAboveNormal, PrecipitationBelowNormal)
+
+		if err != nil {
+			return outlooks, err
+		}
+
+		outlooks = append(outlooks, temps...)
+		outlooks = append(outlooks, precips...)
+	}
+
+	if len(outlooks) == 0 {
+		return outlooks, errors.New("Could not find climate anomalies")
+	}
+
+	return outlooks, nil
+}
+
+// climateOutlooks pairs each above normal section with the below normal
+// section that has the same time layout.  When only one of them is
+// given the other probability is NaN, but when both are given every
+// section must have a partner.
+func (dwml *DWML) climateOutlooks(dp DataParameters, period ClimatePeriod, variable string, above, below ClimateAnomaly) ([]ClimateOutlook, error) {
+	aboveSections := dp.ClimateAnomalySections(period, above)
+	belowSections := dp.ClimateAnomalySections(period, below)
+	layouts := make([]string, 0, len(aboveSections)+len(belowSections))
+	aboveVals := make(map[string][]float64)
+	belowVals := make(map[string][]float64)
+
+	for _, section := range aboveSections {
+		if _, ok := aboveVals[section.TimeLayout]; !ok {
+			layouts = append(layouts, section.TimeLayout)
+		}
+
+		aboveVals[section.TimeLayout] = append(aboveVals[section.TimeLayout], sectionValues(section)...)
+	}
+
+	for _, section := range belowSections {
+		_, inAbove := aboveVals[section.TimeLayout]
+		_, seen := belowVals[section.TimeLayout]
+
+		switch {
+		case !inAbove && len(aboveSections) > 0:
+			return []ClimateOutlook{}, errors.New(fmt.Sprintf("No %s section for time layout %s of %s", above, section.TimeLayout, below))
+		case !inAbove && !seen:
+			layouts = append(layouts, section.TimeLayout)
+		}
+
+		belowVals[section.TimeLayout] = append(belowVals[section.TimeLayout], sectionValues(section)...)
+	}
+
+	if len(belowSections) > 0 {
+		for _, layout := range layouts {
+			if _, ok := belowVals[layout]; !ok {
+				return []ClimateOutlook{}, errors.New(fmt.Sprintf("No %s section for time layout %s of %s", below, layout, above))
+			}
+		}
+	}
+
+	outlooks := make([]ClimateOutlook, 0, len(layouts))
+
+	for _, layout := range layouts {
+		spans, err := dwml.layoutTimeSpans(layout)
+
+		if err != nil {
+			return outlooks, err
+		}
+
+		for i, span := range spans {
+			co := ClimateOutlook{outlookWindow(period, span), span, variable, math.NaN(), math.NaN()}
+
+			if i < len(aboveVals[layout]) {
+				co.AboveNormal = aboveVals[layout][i]
+			}
+
+			if i < len(belowVals[layout]) {
+				co.BelowNormal = belowVals[layout][i]
+			}
+
+			outlooks = append(outlooks, co)
+		}
+	}
+
+	return outlooks, nil
+}
+
+func sectionValues(section DataParametersSection) []float64 {
+	_, _, vals, _ := GetParametersSection([]DataParametersSection{section}, section.Type)
+	return vals
+}
+
+// outlookWindow tells the 6-10 and 8-14 day outlooks apart by the
+// length of their valid period, since both arrive as weekly anomalies.
+func outlookWindow(period ClimatePeriod, span noaa.TimeSpan) OutlookWindow {
+	switch period {
+	case Monthly:
+		return OneMonth
+	case Seasonal:
+		return ThreeMonth
+	}
+
+	d := span.End.Sub(span.Begin)
+
+	if d > 0 && d <= 5*24*time.Hour {
+		return SixToTenDay
+	}
+
+	return EightToFourteen
+}
@@ -0,0 +1,102 @@
+package ndfd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClimateOutlooks(t *testing.T) {
+	dwml := loadDWML(t, "climate-anomaly.xml")
+
+	outlooks, err := dwml.ClimateOutlooks()
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if len(outlooks) != 2 {
+		t.Fatalf("%d climate outlooks returned, but should have received 2", len(outlooks))
+	}
+
+	weekly := outlooks[0]
+
+	if weekly.Window != EightToFourteen || weekly.Variable != "temperature" || weekly.AboveNormal != 60 || weekly.NearNormal() != 33 {
+		t.Errorf("Weekly outlook decoded as %+v", weekly)
+	}
+
+	monthly := outlooks[1]
+
+	if monthly.Window != OneMonth || monthly.Variable != "precipitation" || monthly.BelowNormal != 27 {
+		t.Errorf("Monthly outlook decoded as %+v", monthly)
+	}
+}
+
+func TestWeeklyClimateOutlooks(t *testing.T) {
+	dwml := loadDWML(t, "climate-weekly.xml")
+
+	outlooks, err := dwml.ClimateOutlooks()
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	expected := []struct {
+		window OutlookWindow
+		days   int
+		above  float64
+		below  float64
+	}{
+		{SixToTenDay, 5, 70, 5},
+		{EightToFourteen, 7, 60, 7},
+	}
+
+	if len(outlooks) != len(expected) {
+		t.Fatalf("%d climate outlooks returned, but should have received %d", len(outlooks), len(expected))
+	}
+
+	for i, e := range expected {
+		o := outlooks[i]
+
+		if o.Window != e.window || o.TimeSpan.End.Sub(o.TimeSpan.Begin) != time.Duration(e.days)*24*time.Hour || o.AboveNormal != e.above || o.BelowNormal != e.below {
+			t.Errorf("Outlook %d decoded as %+v", i, o)
+		}
+	}
+
+	if _, _, _, err := dwml.Data.Parameters[0].ClimateAnomalyProbabilities(Weekly, TemperatureAboveNormal); err == nil {
+		t.Errorf("Probabilities joined across the 6-10 and 8-14 day layouts")
+	}
+
+	dwml.Data.Parameters[0].ClimateAnomalies[1].Weekly[1].TimeLayout = "k-p5d-n1-1"
+
+	if _, err := dwml.ClimateOutlooks(); err == nil {
+		t.Errorf("Outlooks returned with above and below normal in different layouts")
+	}
+}
+
+// The DWML schema names the element climate-anomaly, which the fixtures
+// use.  Responses could not be recorded to confirm what the live service
+// sends, so the spelling this package used to decode is still accepted.
+func TestClimateAnomalySpellings(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "climate-anomaly.xml"))
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	for _, tag := range []string{"climate-anomaly", "climate-anomoly"} {
+		dwml, err := ParseDWML(strings.NewReader(strings.ReplaceAll(string(data), "climate-anomaly", tag)))
+
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+
+		outlooks, err := dwml.ClimateOutlooks()
+
+		if err != nil || len(outlooks) != 2 {
+			t.Errorf("%d outlooks decoded from %s: %v", len(outlooks), tag, err)
+		}
+	}
+}
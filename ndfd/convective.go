@@ -0,0 +1,206 @@
+package ndfd
+
+import (
+	"errors"
+	"github.com/gershwinlabs/noaa"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ConvectiveOutlook is a Storm Prediction Center categorical outlook,
+// ordered from least to most severe.
+type ConvectiveOutlook int
+
+const (
+	OutlookUnknown ConvectiveOutlook = iota - 1
+	OutlookNoThunderstorms
+	OutlookGeneralThunderstorms
+	OutlookMarginal
+	OutlookSlight
+	OutlookEnhanced
+	OutlookModerate
+	OutlookHigh
+)
+
+var convectiveOutlookNames = []string{
+	"No Thunderstorms",
+	"General Thunderstorms",
+	"Marginal Risk",
+	"Slight Risk",
+	"Enhanced Risk",
+	"Moderate Risk",
+	"High Risk",
+}
+
+type SevereHazard string
+
+const (
+	Tornadoes                  SevereHazard = "tornadoes"
+	Hail                       SevereHazard = "hail"
+	DamagingWinds              SevereHazard = "damaging thunderstorm winds"
+	ExtremeTornadoes           SevereHazard = "extreme tornadoes"
+	ExtremeHail                SevereHazard = "extreme hail"
+	ExtremeWinds               SevereHazard = "extreme thunderstorm winds"
+	SevereThunderstorms        SevereHazard = "total severe thunderstorms"
+	ExtremeSevereThunderstorms SevereHazard = "extreme total severe thunderstorms"
+)
+
+type OutlookPeriod struct {
+	TimeSpan noaa.TimeSpan
+	Outlook  ConvectiveOutlook
+}
+
+func ParseConvectiveOutlook(s string) ConvectiveOutlook {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	if i, err := strconv.Atoi(s); err == nil {
+		if i < 0 || i >= len(convectiveOutlookNames) {
+			return OutlookUnknown
+		}
+
+		return ConvectiveOutlook(i)
+	}
+
+	switch {
+	case s == "":
+		return OutlookUnknown
+	case strings.Contains(s, "high"):
+		return OutlookHigh
+	case strings.Contains(s, "moderate"):
+		return OutlookModerate
+	case strings.Contains(s, "enhanced"):
+		return OutlookEnhanced
+	case strings.Contains(s, "slight"):
+		return OutlookSlight
+	case strings.Contains(s, "marginal"):
+		return OutlookMarginal
+	case strings.HasPrefix(s, "no "):
+		return OutlookNoThunderstorms
+	case strings.Contains(s, "thunderstorm"):
+		return OutlookGeneralThunderstorms
+	}
+
+	return OutlookUnknown
+}
+
+func (o ConvectiveOutlook) String() string {
+	if o < 0 || int(o) >= len(convectiveOutlookNames) {
+		return "Unknown"
+	}
+
+	return convectiveOutlookNames[o]
+}
+
+func (dp DataParameters) ConvectiveOutlooks() (string, []ConvectiveOutlook, error) {
+	for _, ch := range dp.ConvectiveHazards {
+		if ch.Outlook.TimeLayout == "" {
+			continue
+		}
+
+		outlooks := make([]ConvectiveOutlook, len(ch.Outlook.Values))
+
+		for i, v := range ch.Outlook.Values {
+			outlooks[i] = ParseConvectiveOutlook(v)
+		}
+
+		return ch.Outlook.TimeLayout, outlooks, nil
+	}
+
+	return "unknown", []ConvectiveOutlook{}, errors.New("Could not find convective outlook")
+}
+
+func (dp DataParameters) convectiveOutlookValues() (string, string, []float64, error) {
+	layout, outlooks, err := dp.ConvectiveOutlooks()
+	vals := make([]float64, len(outlooks))
+
+	for i, o := range outlooks {
+		if o == OutlookUnknown {
+			vals[i] = math.NaN()
+		} else {
+			vals[i] = float64(o)
+		}
+	}
+
+	return layout, "category", vals, err
+}
+
+func (dp DataParameters) SevereProbabilities(hazard SevereHazard) (string, string, []float64, error) {
+	sections := make([]DataParametersSection, 0, len(dp.ConvectiveHazards))
+
+	for _, ch := range dp.ConvectiveHazards {
+		sections = append(sections, ch.SevereComponent)
+	}
+
+	return GetParametersSection(sections, string(hazard))
+}
+
+func (dp DataParameters) TornadoProbabilities() (string, string, []float64, error) {
+	return dp.SevereProbabilities(Tornadoes)
+}
+
+func (dp DataParameters) HailProbabilities() (string, string, []float64, error) {
+	return dp.SevereProbabilities(Hail)
+}
+
+func (dp DataParameters) DamagingWindProbabilities() (string, string, []float64, error) {
+	return dp.SevereProbabilities(DamagingWinds)
+}
+
+func (dp DataParameters) ExtremeTornadoProbabilities() (string, string, []float64, error) {
+	return dp.SevereProbabilities(ExtremeTornadoes)
+}
+
+func (dp DataParameters) ExtremeHailProbabilities() (string, string, []float64, error) {
+	return dp.SevereProbabilities(ExtremeHail)
+}
+
+func (dp DataParameters) ExtremeWindProbabilities() (string, string, []float64, error) {
+	return dp.SevereProbabilities(ExtremeWinds)
+}
+
+func (dp DataParameters) SevereThunderstormProbabilities() (string, string, []float64, error) {
+	return dp.SevereProbabilities(SevereThunderstorms)
+}
+
+func (dp DataParameters) ExtremeSevereThunderstormProbabilities() (string, string, []float64, error) {
+	return dp.SevereProbabilities(ExtremeSevereThunderstorms)
+}
+
+func (dwml *DWML) ConvectiveOutlookPeriods() ([]OutlookPeriod, error) {
+	dp, err := dwml.Data.singleParameters()
+
+	if err != nil {
+		return []OutlookPeriod{}, err
+	}
+
+	layout, outlooks, err := dp.ConvectiveOutlooks()
+
+	if err != nil {
+		return []OutlookPeriod{}, err
+	}
+
+	spans, err := dwml.layoutTimeSpans(layout)
+
+	if err != nil {
+		return []OutlookPeriod{}, err
+	}
+
+	periods := make([]OutlookPeriod, 0, len(outlooks))
+
+	for i, o := range outlooks {
+		if o == OutlookUnknown || i >= len(spans) {
+			continue
+		}
+
+		periods = append(periods, OutlookPeriod{spans[i], o})
+	}
+
+	return periods, nil
+}
+
+func (dwml *DWML) SevereProbabilityPeriods(hazard SevereHazard) ([]ValuePeriod, error) {
+	return dwml.sectionPeriods(func(dp DataParameters) (string, string, []float64, error) {
+		return dp.SevereProbabilities(hazard)
+	})
+}
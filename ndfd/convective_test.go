@@ -0,0 +1,64 @@
+package ndfd
+
+import (
+	"testing"
+)
+
+func TestParseConvectiveOutlook(t *testing.T) {
+	tests := map[string]ConvectiveOutlook{
+		"No Thunderstorms":                      OutlookNoThunderstorms,
+		"General Thunderstorms":                 OutlookGeneralThunderstorms,
+		"Marginal Risk of Severe Thunderstorms": OutlookMarginal,
+		"Slight Risk of Severe Thunderstorms":   OutlookSlight,
+		"Enhanced Risk of Severe Thunderstorms": OutlookEnhanced,
+		"High Risk of Severe Thunderstorms":     OutlookHigh,
+		"5":                                     OutlookModerate,
+		"":                                      OutlookUnknown,
+	}
+
+	for s, expected := range tests {
+		if o := ParseConvectiveOutlook(s); o != expected {
+			t.Errorf("%q parsed as %s, expected %s", s, o, expected)
+		}
+	}
+}
+
+func TestConvectiveHazards(t *testing.T) {
+	dwml := loadDWML(t, "convective-hazard.xml")
+
+	outlooks, err := dwml.ConvectiveOutlookPeriods()
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if len(outlooks) != 2 || outlooks[0].Outlook != OutlookModerate || outlooks[1].Outlook != OutlookGeneralThunderstorms {
+		t.Errorf("Convective outlooks decoded as %+v", outlooks)
+	}
+
+	_, units, vals, err := dwml.Data.Parameters[0].TornadoProbabilities()
+
+	if err != nil || units != "percent" || len(vals) != 1 || vals[0] != 15 {
+		t.Errorf("Tornado probabilities decoded as %s %v %v", units, vals, err)
+	}
+
+	if _, _, _, err := dwml.Data.Parameters[0].HailProbabilities(); err == nil {
+		t.Errorf("Hail probabilities should be missing")
+	}
+
+	condChan, err := dwml.Conditions()
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	counts := make(map[string]int)
+
+	for c := range condChan {
+		counts[c.Name]++
+	}
+
+	if counts["conhazo"] != 48 || counts["ptornado"] != 24 || counts["pxtornado"] != 24 {
+		t.Errorf("Unexpected convective conditions %v", counts)
+	}
+}
@@ -0,0 +1,267 @@
+package ndfd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gershwinlabs/noaa"
+	"math"
+	"slices"
+	"sort"
+	"time"
+)
+
+// Change is the difference between two forecasts for one element at one
+// hour.  Old or New is NaN when only one of the forecasts covered the
+// hour.  Units are those of the older forecast.
+type Change struct {
+	Name     string
+	Location string
+	Hour     time.Time
+	Old      float64
+	New      float64
+	Units    string
+}
+
+// Shift is a run of consecutive hours that changed in the same way, such
+// as every hour of a daily maximum temperature period.
+type Shift struct {
+	Name     string
+	Location string
+	TimeSpan noaa.TimeSpan
+	Old      float64
+	New      float64
+	Units    string
+}
+
+// ForecastDiff holds every hourly change between two forecasts, ordered
+// by location, element and hour.  Hours whose value did not change are
+// left out.
+type ForecastDiff struct {
+	OldCreationDate time.Time
+	NewCreationDate time.Time
+	Changes         []Change
+}
+
+// changeKey identifies a condition by its point rather than its location
+// key, since two issuances can list the same points in different orders.
+type changeKey struct {
+	lat  float64
+	lon  float64
+	name string
+	hour time.Time
+}
+
+func (c Change) Delta() float64 {
+	return c.New - c.Old
+}
+
+func (s Shift) Delta() float64 {
+	return s.New - s.Old
+}
+
+func (s Shift) String() string {
+	when := fmt.Sprintf("%s %s to %s", s.Name, s.TimeSpan.Begin.UTC().Format("Mon Jan 2 15:04"), s.TimeSpan.End.UTC().Format("Mon Jan 2 15:04 MST"))
+
+	switch {
+	case math.IsNaN(s.Old):
+		return fmt.Sprintf("%s added at %g %s", when, s.New, s.Units)
+	case math.IsNaN(s.New):
+		return fmt.Sprintf("%s removed, was %g %s", when, s.Old, s.Units)
+	case s.Delta() < 0:
+		return fmt.Sprintf("%s dropped %g %s (%g to %g)", when, -s.Delta(), s.Units, s.Old, s.New)
+	}
+
+	return fmt.Sprintf("%s rose %g %s (%g to %g)", when, s.Delta(), s.Units, s.Old, s.New)
+}
+
+// DiffNDFD compares the forecasts two issuances of NDFD made for the same
+// points.
+func DiffNDFD(older, newer NDFD) (ForecastDiff, error) {
+	if older.Dwml == nil || newer.Dwml == nil {
+		return ForecastDiff{}, errors.New("Cannot diff a forecast without a document")
+	}
+
+	return DiffDWML(older.Dwml, newer.Dwml)
+}
+
+func DiffDWML(older, newer *DWML) (ForecastDiff, error) {
+	if !samePoints(older.Data.Locations, newer.Data.Locations) {
+		return ForecastDiff{}, errors.New("Forecasts are not for the same points")
+	}
+
+	oldConds := slices.Collect(older.Table().All())
+	newConds := slices.Collect(newer.Table().All())
+	diff, err := DiffConditions(oldConds, newConds)
+	diff.OldCreationDate, _ = older.CreationDate()
+	diff.NewCreationDate, _ = newer.CreationDate()
+	return diff, err
+}
+
+// DiffConditions compares two sets of Conditions by point, element and
+// hour.  Newer values are converted to the units of the older ones
+// before comparing.  Changes are labelled with the newer location key
+// for their point.
+func DiffConditions(older, newer []Condition) (ForecastDiff, error) {
+	diff := ForecastDiff{}
+	oldByKey := make(map[changeKey]Condition)
+	labels := make(map[[2]float64]string)
+
+	for _, c := range newer {
+		labels[[2]float64{c.Lat, c.Lon}] = locationLabel(c)
+	}
+
+	for _, c := range older {
+		oldByKey[conditionKey(c)] = c
+
+		if _, ok := labels[[2]float64{c.Lat, c.Lon}]; !ok {
+			labels[[2]float64{c.Lat, c.Lon}] = locationLabel(c)
+		}
+	}
+
+	seen := make(map[changeKey]bool)
+
+	for _, c := range newer {
+		key := conditionKey(c)
+		location := labels[[2]float64{key.lat, key.lon}]
+		seen[key] = true
+		old, ok := oldByKey[key]
+
+		if !ok {
+			diff.Changes = append(diff.Changes, Change{c.Name, location, key.hour, math.NaN(), c.Value, c.Units})
+			continue
+		}
+
+		if c.Units != old.Units {
+			converted, err := c.ConvertTo(old.Unit())
+
+			if err != nil {
+				return diff, err
+			}
+
+			c = converted
+		}
+
+		if c.Value != old.Value {
+			diff.Changes = append(diff.Changes, Change{c.Name, location, key.hour, old.Value, c.Value, old.Units})
+		}
+	}
+
+	for key, c := range oldByKey {
+		if !seen[key] {
+			diff.Changes = append(diff.Changes, Change{c.Name, labels[[2]float64{key.lat, key.lon}], key.hour, c.Value, math.NaN(), c.Units})
+		}
+	}
+
+	sort.Slice(diff.Changes, func(i, j int) bool {
+		a, b := diff.Changes[i], diff.Changes[j]
+
+		if a.Location != b.Location {
+			return a.Location < b.Location
+		}
+
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+
+		return a.Hour.Before(b.Hour)
+	})
+
+	return diff, nil
+}
+
+// Element returns the changes for the element with the given condition
+// name.
+func (d ForecastDiff) Element(name string) []Change {
+	changes := make([]Change, 0)
+
+	for _, c := range d.Changes {
+		if c.Name == name {
+			changes = append(changes, c)
+		}
+	}
+
+	return changes
+}
+
+// Shifts merges consecutive hourly changes with the same old and new
+// values.
+func (d ForecastDiff) Shifts() []Shift {
+	shifts := make([]Shift, 0)
+
+	for _, c := range d.Changes {
+		last := len(shifts) - 1
+
+		if last >= 0 && extendsShift(shifts[last], c) {
+			shifts[last].TimeSpan.End = c.Hour.Add(time.Hour)
+			continue
+		}
+
+		span := noaa.TimeSpan{Begin: c.Hour, End: c.Hour.Add(time.Hour)}
+		shifts = append(shifts, Shift{c.Name, c.Location, span, c.Old, c.New, c.Units})
+	}
+
+	return shifts
+}
+
+// LargestShifts returns up to n shifts with the largest change in value,
+// largest first.  Elements that were only in one of the forecasts are
+// not included.
+func (d ForecastDiff) LargestShifts(n int) []Shift {
+	shifts := make([]Shift, 0)
+
+	for _, s := range d.Shifts() {
+		if !math.IsNaN(s.Delta()) {
+			shifts = append(shifts, s)
+		}
+	}
+
+	sort.SliceStable(shifts, func(i, j int) bool {
+		return math.Abs(shifts[i].Delta()) > math.Abs(shifts[j].Delta())
+	})
+
+	if n < len(shifts) {
+		shifts = shifts[:n]
+	}
+
+	return shifts
+}
+
+func extendsShift(s Shift, c Change) bool {
+	return s.Name == c.Name && s.Location == c.Location && s.TimeSpan.End.Equal(c.Hour) && sameValue(s.Old, c.Old) && sameValue(s.New, c.New)
+}
+
+func sameValue(a, b float64) bool {
+	return a == b || (math.IsNaN(a) && math.IsNaN(b))
+}
+
+func conditionKey(c Condition) changeKey {
+	return changeKey{c.Lat, c.Lon, c.Name, c.Hour.UTC()}
+}
+
+func locationLabel(c Condition) string {
+	if c.Location == "" {
+		return fmt.Sprintf("%f,%f", c.Lat, c.Lon)
+	}
+
+	return c.Location
+}
+
+func samePoints(a, b []DataLocation) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	points := make(map[DataLocationPoint]bool)
+
+	for _, loc := range a {
+		points[loc.Point] = true
+	}
+
+	for _, loc := range b {
+		if !points[loc.Point] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,113 @@
+package ndfd
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiffDWML(t *testing.T) {
+	older := loadDWML(t, "time-series.xml")
+	newer := loadDWML(t, "time-series.xml")
+	newer.Head.Product.CreationDate.Value = "2016-03-02T00:48:26Z"
+	newer.Data.Parameters[0].Temperatures[0].Values[1] = "-5"
+
+	diff, err := DiffDWML(older, newer)
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if !diff.NewCreationDate.Equal(time.Date(2016, 3, 2, 0, 48, 26, 0, time.UTC)) || !diff.NewCreationDate.After(diff.OldCreationDate) {
+		t.Errorf("Creation dates decoded as %s and %s", diff.OldCreationDate, diff.NewCreationDate)
+	}
+
+	if len(diff.Changes) != 12 || len(diff.Element("maxt")) != 12 {
+		t.Fatalf("Unexpected changes %+v", diff.Changes)
+	}
+
+	shifts := diff.LargestShifts(3)
+
+	if len(shifts) != 1 || shifts[0].Delta() != -4 || shifts[0].TimeSpan.Begin.Hour() != 14 || shifts[0].TimeSpan.End.Sub(shifts[0].TimeSpan.Begin) != 12*time.Hour {
+		t.Errorf("Unexpected shifts %+v", shifts)
+	}
+
+	if !strings.Contains(shifts[0].String(), "maxt") || !strings.Contains(shifts[0].String(), "dropped 4 Celsius") {
+		t.Errorf("Shift described as %s", shifts[0])
+	}
+
+	other := loadDWML(t, "multi-point.xml")
+
+	if _, err := DiffDWML(older, other); err == nil {
+		t.Errorf("Forecasts for different points should not diff")
+	}
+}
+
+func TestDiffSwappedLocations(t *testing.T) {
+	older := loadDWML(t, "multi-point.xml")
+	newer := loadDWML(t, "multi-point.xml")
+	newer.Data.Locations[0].LocationKey, newer.Data.Locations[1].LocationKey = "point2", "point1"
+	newer.Data.Parameters[0].ApplicableLocation, newer.Data.Parameters[1].ApplicableLocation = "point2", "point1"
+
+	diff, err := DiffDWML(older, newer)
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if len(diff.Changes) != 0 {
+		t.Errorf("Swapped location keys reported as changes %+v", diff.Changes)
+	}
+
+	newer.Data.Parameters[1].Temperatures[0].Values[0] = "30"
+	diff, err = DiffDWML(older, newer)
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	for _, c := range diff.Changes {
+		if c.Location != "point1" || c.New != 30 {
+			t.Errorf("Change attributed to the wrong point: %+v", c)
+		}
+	}
+
+	if len(diff.Changes) == 0 {
+		t.Errorf("Change to point1's temperature not reported")
+	}
+}
+
+func TestDiffConditions(t *testing.T) {
+	hour := time.Date(2016, 3, 2, 0, 0, 0, 0, time.UTC)
+	older := []Condition{
+		{"temp", 10, "Celsius", hour, 39.64, -106.37, "point1"},
+		{"rh", 40, "percent", hour, 39.64, -106.37, "point1"},
+	}
+	newer := []Condition{
+		{"temp", 50, "Fahrenheit", hour, 39.64, -106.37, "point1"},
+		{"wgust", 12, "meters/second", hour, 39.64, -106.37, "point1"},
+	}
+
+	diff, err := DiffConditions(older, newer)
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if len(diff.Changes) != 2 {
+		t.Fatalf("Unexpected changes %+v", diff.Changes)
+	}
+
+	if diff.Changes[0].Name != "rh" || !math.IsNaN(diff.Changes[0].New) {
+		t.Errorf("Removed element decoded as %+v", diff.Changes[0])
+	}
+
+	if diff.Changes[1].Name != "wgust" || !math.IsNaN(diff.Changes[1].Old) {
+		t.Errorf("Added element decoded as %+v", diff.Changes[1])
+	}
+
+	if len(diff.LargestShifts(5)) != 0 {
+		t.Errorf("Added and removed elements should not be shifts")
+	}
+}
@@ -0,0 +1,471 @@
+package ndfd
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	dwmlVersion        = "1.0"
+	xsdNamespace       = "http://www.w3.org/2001/XMLSchema"
+	xsiNamespace       = "http://www.w3.org/2001/XMLSchema-instance"
+	dwmlSchemaLocation = "http://graphical.weather.gov/xml/DWMLgen/schema/DWML.xsd"
+)
+
+var nilAttr = xml.Attr{Name: xml.Name{Local: "xsi:nil"}, Value: "true"}
+
+// Encode writes the document as DWML, including the XML declaration.
+func (dwml *DWML) Encode(w io.Writer) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	if err := enc.Encode(dwml); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func (dwml DWML) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	type document DWML
+
+	start.Name = xml.Name{Local: "dwml"}
+	start.Attr = []xml.Attr{
+		{Name: xml.Name{Local: "version"}, Value: dwmlVersion},
+		{Name: xml.Name{Local: "xmlns:xsd"}, Value: xsdNamespace},
+		{Name: xml.Name{Local: "xmlns:xsi"}, Value: xsiNamespace},
+		{Name: xml.Name{Local: "xsi:noNamespaceSchemaLocation"}, Value: dwmlSchemaLocation},
+	}
+
+	return e.EncodeElement(document(dwml), start)
+}
+
+// MarshalXML interleaves the start and end times the way NDFD does,
+// rather than writing all of the start times first.
+func (tl DataTimeLayout) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Attr = []xml.Attr{
+		{Name: xml.Name{Local: "time-coordinate"}, Value: tl.TimeCoordinate},
+		{Name: xml.Name{Local: "summarization"}, Value: tl.Summarization},
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if err := encodeElement(e, "layout-key", tl.LayoutKey); err != nil {
+		return err
+	}
+
+	for i, begin := range tl.StartValidTimes {
+		if err := encodeElement(e, "start-valid-time", begin); err != nil {
+			return err
+		}
+
+		if i < len(tl.EndValidTimes) {
+			if err := encodeElement(e, "end-valid-time", tl.EndValidTimes[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// MarshalXML omits sections that were never decoded, such as the unused
+// half of a convective-hazard, and writes missing values as nil.
+func (dps DataParametersSection) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if dps.TimeLayout == "" && len(dps.Values) == 0 {
+		return nil
+	}
+
+	start.Attr = appendAttr(start.Attr, "type", dps.Type)
+	start.Attr = appendAttr(start.Attr, "units", dps.Units)
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "time-layout"}, Value: dps.TimeLayout})
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if err := encodeElement(e, "name", dps.Name); err != nil {
+		return err
+	}
+
+	if err := encodeNillable(e, "value", dps.Values); err != nil {
+		return err
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+func (ci DataParametersConditionsIcon) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if ci.TimeLayout == "" && len(ci.IconLink) == 0 {
+		return nil
+	}
+
+	start.Attr = appendAttr(start.Attr, "type", ci.Type)
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "time-layout"}, Value: ci.TimeLayout})
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if err := encodeElement(e, "name", ci.Name); err != nil {
+		return err
+	}
+
+	if err := encodeNillable(e, "icon-link", ci.IconLink); err != nil {
+		return err
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+func (w DataParametersWeather) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	type weather DataParametersWeather
+
+	if w.TimeLayout == "" && len(w.WeatherConditions) == 0 {
+		return nil
+	}
+
+	return e.EncodeElement(weather(w), start)
+}
+
+func (v DataParametersWeatherConditionsValueVisibility) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	type visibility DataParametersWeatherConditionsValueVisibility
+
+	if v.Value == "" {
+		start.Attr = append(start.Attr, nilAttr)
+	}
+
+	return e.EncodeElement(visibility(v), start)
+}
+
+func (wf DataParametersWordedForecast) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	type wordedForecast DataParametersWordedForecast
+
+	if wf.TimeLayout == "" && len(wf.Texts) == 0 {
+		return nil
+	}
+
+	return e.EncodeElement(wordedForecast(wf), start)
+}
+
+func (h DataParametersHazards) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	type hazards DataParametersHazards
+
+	if h.TimeLayout == "" && len(h.HazardConditions) == 0 {
+		return nil
+	}
+
+	return e.EncodeElement(hazards(h), start)
+}
+
+func (ws DataParametersWaterState) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	type waterState DataParametersWaterState
+
+	if ws.TimeLayout == "" && ws.Waves.TimeLayout == "" && len(ws.Waves.Values) == 0 {
+		return nil
+	}
+
+	return e.EncodeElement(waterState(ws), start)
+}
+
+// UnmarshalXML trims the production center name, since indented output
+// places whitespace around its sub-center.
+func (pc *HeadSourceProductionCenter) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type productionCenter HeadSourceProductionCenter
+	var decoded productionCenter
+
+	if err := d.DecodeElement(&decoded, &start); err != nil {
+		return err
+	}
+
+	*pc = HeadSourceProductionCenter(decoded)
+	pc.Value = strings.TrimSpace(pc.Value)
+	return nil
+}
+
+func appendAttr(attrs []xml.Attr, name, value string) []xml.Attr {
+	if value == "" {
+		return attrs
+	}
+
+	return append(attrs, xml.Attr{Name: xml.Name{Local: name}, Value: value})
+}
+
+func encodeElement(e *xml.Encoder, name, value string) error {
+	return e.EncodeElement(value, xml.StartElement{Name: xml.Name{Local: name}})
+}
+
+func encodeNillable(e *xml.Encoder, name string, values []string) error {
+	for _, v := range values {
+		start := xml.StartElement{Name: xml.Name{Local: name}}
+
+		if v == "" {
+			start.Attr = []xml.Attr{nilAttr}
+		}
+
+		if err := e.EncodeElement(v, start); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// encodedElement describes where BuildDWML writes the Conditions with
+// a given name.  Period elements cover every hour of forecast periods of
+// up to period hours, while the others, with a period of zero, are valid
+// at a single hour.
+type encodedElement struct {
+	element  string
+	kind     string
+	title    string
+	period   int
+	category func(float64) string
+}
+
+var encodedElements = map[string]encodedElement{
+	"temp":         {"temperature", "hourly", "Temperature", 0, nil},
+	"dewpoint":     {"temperature", "dew point", "Dew Point Temperature", 0, nil},
+	"maxt":         {"temperature", "maximum", "Daily Maximum Temperature", 24, nil},
+	"mint":         {"temperature", "minimum", "Daily Minimum Temperature", 24, nil},
+	"pop12":        {"probability-of-precipitation", "12 hour", "12 Hourly Probability of Precipitation", 12, nil},
+	"clouds":       {"cloud-amount", "total", "Cloud Cover Amount", 0, nil},
+	"precip":       {"precipitation", "liquid", "Liquid Precipitation Amount", 6, nil},
+	"windspeed":    {"wind-speed", "sustained", "Wind Speed", 0, nil},
+	"winddir":      {"direction", "wind", "Wind Direction", 0, nil},
+	"snow":         {"precipitation", "snow", "Snow Amount", 6, nil},
+	"rh":           {"humidity", "relative", "Relative Humidity", 0, nil},
+	"wgust":        {"wind-speed", "gust", "Wind Speed Gust", 0, nil},
+	"conhazo":      {"outlook", "", "Convective Hazard Outlook", 24, func(v float64) string { return ConvectiveOutlook(v).String() }},
+	"ptornado":     {"severe-component", string(Tornadoes), "Probability of Tornadoes", 24, nil},
+	"phail":        {"severe-component", string(Hail), "Probability of Hail", 24, nil},
+	"ptstmwinds":   {"severe-component", string(DamagingWinds), "Probability of Damaging Thunderstorm Winds", 24, nil},
+	"pxtornado":    {"severe-component", string(ExtremeTornadoes), "Probability of Extreme Tornadoes", 24, nil},
+	"pxhail":       {"severe-component", string(ExtremeHail), "Probability of Extreme Hail", 24, nil},
+	"pxtstmwinds":  {"severe-component", string(ExtremeWinds), "Probability of Extreme Thunderstorm Winds", 24, nil},
+	"ptotsvrtstm":  {"severe-component", string(SevereThunderstorms), "Probability of Severe Thunderstorms", 24, nil},
+	"pxtotsvrtstm": {"severe-component", string(ExtremeSevereThunderstorms), "Probability of Extreme Severe Thunderstorms", 24, nil},
+	"incw34":       {"wind-speed", "incremental34", "Probability of a Tropical Cyclone Wind Speed >34 Knots (Incremental)", 6, nil},
+	"incw50":       {"wind-speed", "incremental50", "Probability of a Tropical Cyclone Wind Speed >50 Knots (Incremental)", 6, nil},
+	"incw64":       {"wind-speed", "incremental64", "Probability of a Tropical Cyclone Wind Speed >64 Knots (Incremental)", 6, nil},
+	"cumw34":       {"wind-speed", "cumulative34", "Probability of a Tropical Cyclone Wind Speed >34 Knots (Cumulative)", 6, nil},
+	"cumw50":       {"wind-speed", "cumulative50", "Probability of a Tropical Cyclone Wind Speed >50 Knots (Cumulative)", 6, nil},
+	"cumw64":       {"wind-speed", "cumulative64", "Probability of a Tropical Cyclone Wind Speed >64 Knots (Cumulative)", 6, nil},
+	"critfireo":    {"fire-weather", "risk from wind and relative humidity", "Fire Weather Outlook from Wind and Relative Humidity", 24, func(v float64) string { return FireRisk(v).String() }},
+	"dryfireo":     {"fire-weather", "risk from dry thunderstorms", "Fire Weather Outlook from Dry Thunderstorms", 24, func(v float64) string { return DryThunderstormRisk(v).String() }},
+}
+
+// encodedRun is a value that holds from begin until end.  Instantaneous
+// values have a zero end.
+type encodedRun struct {
+	begin time.Time
+	end   time.Time
+	value float64
+}
+
+// BuildDWML turns Conditions, such as those read from Conditions() and
+// post-processed, back into a DWML document with UTC time layouts.
+// Conditions for each location are grouped by name, and runs of equal
+// hourly values for period elements are merged into a single period.
+func BuildDWML(conds []Condition) (*DWML, error) {
+	dwml := &DWML{Head: defaultHead(time.Now())}
+	locations := make(map[string]int)
+	grouped := make([]map[string][]Condition, 0)
+
+	for _, c := range conds {
+		if _, ok := encodedElements[c.Name]; !ok {
+			return dwml, errors.New(fmt.Sprintf("No DWML element for condition %s", c.Name))
+		}
+
+		key := c.Location
+
+		if key == "" {
+			key = fmt.Sprintf("%f,%f", c.Lat, c.Lon)
+		}
+
+		i, ok := locations[key]
+
+		if !ok {
+			i = len(dwml.Data.Locations)
+			locations[key] = i
+			grouped = append(grouped, make(map[string][]Condition))
+			dwml.Data.Locations = append(dwml.Data.Locations, DataLocation{
+				LocationKey: fmt.Sprintf("point%d", i+1),
+				Point:       DataLocationPoint{c.Lat, c.Lon},
+			})
+
+			if c.Location != "" {
+				dwml.Data.Locations[i].LocationKey = c.Location
+			}
+		}
+
+		grouped[i][c.Name] = append(grouped[i][c.Name], c)
+	}
+
+	layouts := make(map[string]string)
+
+	for i, loc := range dwml.Data.Locations {
+		dp := DataParameters{ApplicableLocation: loc.LocationKey}
+
+		for _, section := range conditionSections {
+			named := grouped[i][section.name]
+
+			if len(named) == 0 {
+				continue
+			}
+
+			el := encodedElements[section.name]
+			runs, units, err := conditionRuns(named, el.period)
+
+			if err != nil {
+				return dwml, err
+			}
+
+			sec := DataParametersSection{Type: el.kind, Units: units, Name: el.title}
+			sec.TimeLayout = dwml.addTimeLayout(layouts, runs)
+
+			for _, r := range runs {
+				if el.category != nil {
+					sec.Values = append(sec.Values, el.category(r.value))
+					sec.Units = ""
+				} else {
+					sec.Values = append(sec.Values, strconv.FormatFloat(r.value, 'f', -1, 64))
+				}
+			}
+
+			dp.addSection(el.element, sec)
+		}
+
+		dwml.Data.Parameters = append(dwml.Data.Parameters, dp)
+	}
+
+	return dwml, nil
+}
+
+func defaultHead(created time.Time) Head {
+	var head Head
+	head.Product.SrsName = "WGS 1984"
+	head.Product.ConciseName = "time-series"
+	head.Product.OperationalMode = "official"
+	head.Product.Title = "NOAA's National Weather Service Forecast Data"
+	head.Product.Field = "meteorological"
+	head.Product.Category = "forecast"
+	head.Product.CreationDate.Value = created.UTC().Format(time.RFC3339)
+	head.Source.MoreInformation = "http://graphical.weather.gov/xml/"
+	return head
+}
+
+// conditionRuns turns hourly conditions back into the values of their
+// forecast periods.  Conditions only record the hours a period covered,
+// so consecutive hours with the same value are joined into periods of at
+// most the element's period hours, which splits equal neighbouring
+// periods at their original boundaries.  Instantaneous elements, with a
+// period of zero, are never joined.
+func conditionRuns(conds []Condition, period int) ([]encodedRun, string, error) {
+	sorted := make([]Condition, len(conds))
+	copy(sorted, conds)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Hour.Before(sorted[j].Hour) })
+
+	units := sorted[0].Units
+	runs := make([]encodedRun, 0, len(sorted))
+
+	for _, c := range sorted {
+		if c.Units != units {
+			return runs, units, errors.New(fmt.Sprintf("Conditions for %s mix units %s and %s", c.Name, units, c.Units))
+		}
+
+		if math.IsNaN(c.Value) {
+			continue
+		}
+
+		hour := c.Hour.UTC()
+
+		if period == 0 {
+			runs = append(runs, encodedRun{hour, time.Time{}, c.Value})
+			continue
+		}
+
+		last := len(runs) - 1
+
+		if last >= 0 && runs[last].end.Equal(hour) && runs[last].value == c.Value && runs[last].end.Sub(runs[last].begin) < time.Duration(period)*time.Hour {
+			runs[last].end = hour.Add(time.Hour)
+		} else {
+			runs = append(runs, encodedRun{hour, hour.Add(time.Hour), c.Value})
+		}
+	}
+
+	return runs, units, nil
+}
+
+// addTimeLayout returns the key of a layout matching the runs, adding one
+// to the document when no earlier element used the same times.
+func (dwml *DWML) addTimeLayout(layouts map[string]string, runs []encodedRun) string {
+	starts := make([]string, len(runs))
+	ends := make([]string, 0, len(runs))
+
+	for i, r := range runs {
+		starts[i] = r.begin.Format(time.RFC3339)
+
+		if !r.end.IsZero() {
+			ends = append(ends, r.end.Format(time.RFC3339))
+		}
+	}
+
+	signature := strings.Join(starts, " ") + "/" + strings.Join(ends, " ")
+
+	if key, ok := layouts[signature]; ok {
+		return key
+	}
+
+	hours := 1
+
+	if len(runs) > 0 && !runs[0].end.IsZero() {
+		hours = int(runs[0].end.Sub(runs[0].begin).Hours())
+	} else if len(runs) > 1 {
+		hours = int(runs[1].begin.Sub(runs[0].begin).Hours())
+	}
+
+	key := fmt.Sprintf("k-p%dh-n%d-%d", hours, len(runs), len(dwml.Data.TimeLayouts)+1)
+	layouts[signature] = key
+	dwml.Data.TimeLayouts = append(dwml.Data.TimeLayouts, DataTimeLayout{"UTC", "none", key, starts, ends})
+	return key
+}
+
+func (dp *DataParameters) addSection(element string, sec DataParametersSection) {
+	switch element {
+	case "temperature":
+		dp.Temperatures = append(dp.Temperatures, sec)
+	case "precipitation":
+		dp.Precipitations = append(dp.Precipitations, sec)
+	case "probability-of-precipitation":
+		dp.ProbabilitiesOfPrecipitation = append(dp.ProbabilitiesOfPrecipitation, sec)
+	case "fire-weather":
+		dp.FireWeathers = append(dp.FireWeathers, sec)
+	case "outlook":
+		dp.ConvectiveHazards = append(dp.ConvectiveHazards, DataParametersConvectiveHazard{Outlook: sec})
+	case "severe-component":
+		dp.ConvectiveHazards = append(dp.ConvectiveHazards, DataParametersConvectiveHazard{SevereComponent: sec})
+	case "wind-speed":
+		dp.WindSpeeds = append(dp.WindSpeeds, sec)
+	case "direction":
+		dp.Directions = append(dp.Directions, sec)
+	case "cloud-amount":
+		dp.CloudAmounts = append(dp.CloudAmounts, sec)
+	case "humidity":
+		dp.Humidities = append(dp.Humidities, sec)
+	}
+}
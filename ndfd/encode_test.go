@@ -0,0 +1,174 @@
+package ndfd
+
+import (
+	"bytes"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncodeRoundTrip(t *testing.T) {
+	fixtures := []string{"time-series.xml", "conditions-icons.xml", "convective-hazard.xml", "multi-point.xml"}
+
+	for _, fixture := range fixtures {
+		dwml := loadDWML(t, fixture)
+		var buf bytes.Buffer
+
+		if err := dwml.Encode(&buf); err != nil {
+			t.Errorf("%s: %s", fixture, err)
+			continue
+		}
+
+		decoded, err := ParseDWML(&buf)
+
+		if err != nil {
+			t.Errorf("%s: %s", fixture, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(dwml, decoded) {
+			t.Errorf("%s did not survive encoding:\n%+v\n%+v", fixture, dwml, decoded)
+		}
+	}
+}
+
+func TestEncodeNilValues(t *testing.T) {
+	dwml := loadDWML(t, "time-series.xml")
+	var buf bytes.Buffer
+
+	if err := dwml.Encode(&buf); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	out := buf.String()
+
+	if !strings.HasPrefix(out, "<?xml") || !strings.Contains(out, `<dwml version="1.0" xmlns:xsd=`) {
+		t.Errorf("Unexpected document start %s", out[:120])
+	}
+
+	if !strings.Contains(out, `<visibility xsi:nil="true"></visibility>`) {
+		t.Errorf("Empty visibility was not written as nil")
+	}
+
+	if strings.Contains(out, "<severe-component></severe-component>") || strings.Contains(out, "<hazards>") {
+		t.Errorf("Empty sections were encoded")
+	}
+}
+
+func TestBuildDWML(t *testing.T) {
+	dwml := loadDWML(t, "time-series.xml")
+	original := collectAll(t, dwml)
+	built, err := BuildDWML(original)
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	var buf bytes.Buffer
+
+	if err := built.Encode(&buf); err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	decoded, err := ParseDWML(&buf)
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	rebuilt := collectAll(t, decoded)
+
+	if len(rebuilt) != len(original) {
+		t.Fatalf("Built document has %d conditions, expected %d", len(rebuilt), len(original))
+	}
+
+	seen := make(map[Condition]int)
+
+	for _, c := range original {
+		seen[c]++
+	}
+
+	for _, c := range rebuilt {
+		if seen[c] == 0 {
+			t.Errorf("Unexpected condition %+v", c)
+		}
+
+		seen[c]--
+	}
+
+	if _, err := BuildDWML([]Condition{{"visibility", 1, "miles", time.Now(), 0, 0, ""}}); err == nil {
+		t.Errorf("Unknown condition should not build")
+	}
+}
+
+func collectAll(t *testing.T, dwml *DWML) []Condition {
+	condChan, err := dwml.Conditions()
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	conds := make([]Condition, 0)
+
+	for c := range condChan {
+		conds = append(conds, c)
+	}
+
+	return conds
+}
+
+func TestBuildDWMLPeriods(t *testing.T) {
+	begin := time.Date(2016, 3, 2, 0, 0, 0, 0, time.UTC)
+	conds := make([]Condition, 0)
+
+	for h := 0; h < 24; h++ {
+		hour := begin.Add(time.Duration(h) * time.Hour)
+
+		if h < 12 {
+			conds = append(conds, Condition{Name: "precip", Value: 2.5, Units: "millimeters", Hour: hour, Location: "point1"})
+		}
+
+		conds = append(conds, Condition{Name: "pop12", Value: 20, Units: "percent", Hour: hour, Location: "point1"})
+	}
+
+	dwml, err := BuildDWML(conds)
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	expected := map[string][]string{
+		"precip": {"2016-03-02T00:00:00Z", "2016-03-02T06:00:00Z", "2016-03-02T06:00:00Z", "2016-03-02T12:00:00Z"},
+		"pop12":  {"2016-03-02T00:00:00Z", "2016-03-02T12:00:00Z", "2016-03-02T12:00:00Z", "2016-03-03T00:00:00Z"},
+	}
+	values := map[string][]float64{"precip": {2.5, 2.5}, "pop12": {20, 20}}
+	accessors := map[string]sectionAccessor{"precip": DataParameters.HourlyLiquidPrecip, "pop12": DataParameters.TwelveHourPoPs}
+
+	for name, accessor := range accessors {
+		layout, _, vals, err := accessor(dwml.Data.Parameters[0])
+
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+
+		var tl DataTimeLayout
+
+		for _, l := range dwml.Data.TimeLayouts {
+			if l.LayoutKey == layout {
+				tl = l
+			}
+		}
+
+		times := make([]string, 0)
+
+		for i := range tl.StartValidTimes {
+			times = append(times, tl.StartValidTimes[i], tl.EndValidTimes[i])
+		}
+
+		if !slices.Equal(times, expected[name]) || !slices.Equal(vals, values[name]) {
+			t.Errorf("%s rebuilt in %s as %v %v", name, layout, times, vals)
+		}
+	}
+}
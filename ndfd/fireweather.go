@@ -0,0 +1,344 @@
+package ndfd
+
+import (
+	"errors"
+	"github.com/gershwinlabs/noaa"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FireRisk is a Storm Prediction Center fire weather outlook category
+// for wind and relative humidity, ordered from least to most severe.
+type FireRisk int
+
+const (
+	FireRiskUnknown FireRisk = iota - 1
+	FireRiskNone
+	FireRiskElevated
+	FireRiskCritical
+	FireRiskExtremelyCritical
+)
+
+var fireRiskNames = []string{
+	"No Critical Fire Weather",
+	"Elevated Fire Weather",
+	"Critical Fire Weather",
+	"Extremely Critical Fire Weather",
+}
+
+// DryThunderstormRisk is a Storm Prediction Center fire weather outlook
+// category for dry thunderstorms, ordered from least to most severe.
+type DryThunderstormRisk int
+
+const (
+	DryThunderstormsUnknown DryThunderstormRisk = iota - 1
+	DryThunderstormsNone
+	DryThunderstormsIsolated
+	DryThunderstormsScattered
+)
+
+var dryThunderstormRiskNames = []string{
+	"No Dry Thunderstorms",
+	"Isolated Dry Thunderstorms",
+	"Scattered Dry Thunderstorms",
+}
+
+type FireRiskPeriod struct {
+	TimeSpan noaa.TimeSpan
+	Risk     FireRisk
+}
+
+type DryThunderstormRiskPeriod struct {
+	TimeSpan noaa.TimeSpan
+	Risk     DryThunderstormRisk
+}
+
+// FireWeatherDay combines the fire weather outlooks for a day with the
+// humidity and wind forecast over the same period.  Values that are
+// not forecast for the day are NaN.
+type FireWeatherDay struct {
+	TimeSpan              noaa.TimeSpan
+	Risk                  FireRisk
+	DryThunderstorms      DryThunderstormRisk
+	MinRelativeHumidity   float64
+	MaxWindSpeed          float64
+	MaxWindGust           float64
+	RelativeHumidityUnits string
+	WindSpeedUnits        string
+}
+
+func ParseFireRisk(s string) FireRisk {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	if i, err := strconv.Atoi(s); err == nil {
+		if i < 0 || i >= len(fireRiskNames) {
+			return FireRiskUnknown
+		}
+
+		return FireRisk(i)
+	}
+
+	switch {
+	case s == "":
+		return FireRiskUnknown
+	case strings.HasPrefix(s, "no "):
+		return FireRiskNone
+	case strings.Contains(s, "extreme"):
+		return FireRiskExtremelyCritical
+	case strings.Contains(s, "critical"):
+		return FireRiskCritical
+	case strings.Contains(s, "elevated"):
+		return FireRiskElevated
+	}
+
+	return FireRiskUnknown
+}
+
+func (r FireRisk) String() string {
+	if r < 0 || int(r) >= len(fireRiskNames) {
+		return "Unknown"
+	}
+
+	return fireRiskNames[r]
+}
+
+func ParseDryThunderstormRisk(s string) DryThunderstormRisk {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	if i, err := strconv.Atoi(s); err == nil {
+		if i < 0 || i >= len(dryThunderstormRiskNames) {
+			return DryThunderstormsUnknown
+		}
+
+		return DryThunderstormRisk(i)
+	}
+
+	switch {
+	case s == "":
+		return DryThunderstormsUnknown
+	case strings.HasPrefix(s, "no "):
+		return DryThunderstormsNone
+	case strings.Contains(s, "scattered"):
+		return DryThunderstormsScattered
+	case strings.Contains(s, "isolated"):
+		return DryThunderstormsIsolated
+	}
+
+	return DryThunderstormsUnknown
+}
+
+func (r DryThunderstormRisk) String() string {
+	if r < 0 || int(r) >= len(dryThunderstormRiskNames) {
+		return "Unknown"
+	}
+
+	return dryThunderstormRiskNames[r]
+}
+
+func (dp DataParameters) FireRisks() (string, []FireRisk, error) {
+	layout, _, strs, err := GetParametersSectionStrings(dp.FireWeathers, "risk from wind and relative humidity")
+	risks := make([]FireRisk, len(strs))
+
+	for i, s := range strs {
+		risks[i] = ParseFireRisk(s)
+	}
+
+	return layout, risks, err
+}
+
+func (dp DataParameters) DryThunderstormRisks() (string, []DryThunderstormRisk, error) {
+	layout, _, strs, err := GetParametersSectionStrings(dp.FireWeathers, "risk from dry thunderstorms")
+	risks := make([]DryThunderstormRisk, len(strs))
+
+	for i, s := range strs {
+		risks[i] = ParseDryThunderstormRisk(s)
+	}
+
+	return layout, risks, err
+}
+
+func (dp DataParameters) fireRiskValues() (string, string, []float64, error) {
+	layout, risks, err := dp.FireRisks()
+	vals := make([]float64, len(risks))
+
+	for i, r := range risks {
+		if r == FireRiskUnknown {
+			vals[i] = math.NaN()
+		} else {
+			vals[i] = float64(r)
+		}
+	}
+
+	return layout, "category", vals, err
+}
+
+func (dp DataParameters) dryThunderstormRiskValues() (string, string, []float64, error) {
+	layout, risks, err := dp.DryThunderstormRisks()
+	vals := make([]float64, len(risks))
+
+	for i, r := range risks {
+		if r == DryThunderstormsUnknown {
+			vals[i] = math.NaN()
+		} else {
+			vals[i] = float64(r)
+		}
+	}
+
+	return layout, "category", vals, err
+}
+
+func (dwml *DWML) FireRiskPeriods() ([]FireRiskPeriod, error) {
+	dp, err := dwml.Data.singleParameters()
+
+	if err != nil {
+		return []FireRiskPeriod{}, err
+	}
+
+	layout, risks, err := dp.FireRisks()
+
+	if err != nil {
+		return []FireRiskPeriod{}, err
+	}
+
+	spans, err := dwml.layoutTimeSpans(layout)
+
+	if err != nil {
+		return []FireRiskPeriod{}, err
+	}
+
+	periods := make([]FireRiskPeriod, 0, len(risks))
+
+	for i, r := range risks {
+		if r == FireRiskUnknown || i >= len(spans) {
+			continue
+		}
+
+		periods = append(periods, FireRiskPeriod{spans[i], r})
+	}
+
+	return periods, nil
+}
+
+func (dwml *DWML) DryThunderstormRiskPeriods() ([]DryThunderstormRiskPeriod, error) {
+	dp, err := dwml.Data.singleParameters()
+
+	if err != nil {
+		return []DryThunderstormRiskPeriod{}, err
+	}
+
+	layout, risks, err := dp.DryThunderstormRisks()
+
+	if err != nil {
+		return []DryThunderstormRiskPeriod{}, err
+	}
+
+	spans, err := dwml.layoutTimeSpans(layout)
+
+	if err != nil {
+		return []DryThunderstormRiskPeriod{}, err
+	}
+
+	periods := make([]DryThunderstormRiskPeriod, 0, len(risks))
+
+	for i, r := range risks {
+		if r == DryThunderstormsUnknown || i >= len(spans) {
+			continue
+		}
+
+		periods = append(periods, DryThunderstormRiskPeriod{spans[i], r})
+	}
+
+	return periods, nil
+}
+
+// FireWeatherSummary returns one FireWeatherDay for each period of the
+// fire weather outlooks.
+func (dwml *DWML) FireWeatherSummary() ([]FireWeatherDay, error) {
+	fireRisks, fireErr := dwml.FireRiskPeriods()
+	dryRisks, dryErr := dwml.DryThunderstormRiskPeriods()
+
+	if fireErr != nil && dryErr != nil {
+		return []FireWeatherDay{}, errors.New("Could not find fire weather outlooks")
+	}
+
+	days := make([]FireWeatherDay, 0, len(fireRisks)+len(dryRisks))
+
+	for _, fr := range fireRisks {
+		day := FireWeatherDay{TimeSpan: fr.TimeSpan, Risk: fr.Risk, DryThunderstorms: DryThunderstormsUnknown}
+		days = append(days, day)
+	}
+
+	for _, dr := range dryRisks {
+		found := false
+
+		for i := range days {
+			if days[i].TimeSpan.Begin.Equal(dr.TimeSpan.Begin) {
+				days[i].DryThunderstorms = dr.Risk
+				found = true
+			}
+		}
+
+		if !found {
+			days = append(days, FireWeatherDay{TimeSpan: dr.TimeSpan, Risk: FireRiskUnknown, DryThunderstorms: dr.Risk})
+		}
+	}
+
+	minRH, rhUnits := dwml.dailyExtremes(days, DataParameters.MinimumRelativeHumidities, math.Min)
+
+	if rhUnits == "" {
+		minRH, rhUnits = dwml.dailyExtremes(days, DataParameters.HourlyRelativeHumidities, math.Min)
+	}
+
+	maxWind, windUnits := dwml.dailyExtremes(days, DataParameters.HourlyWindSpeeds, math.Max)
+	maxGust, _ := dwml.dailyExtremes(days, DataParameters.HourlyWindGusts, math.Max)
+
+	for i := range days {
+		days[i].MinRelativeHumidity = minRH[i]
+		days[i].MaxWindSpeed = maxWind[i]
+		days[i].MaxWindGust = maxGust[i]
+		days[i].RelativeHumidityUnits = rhUnits
+		days[i].WindSpeedUnits = windUnits
+	}
+
+	return days, nil
+}
+
+// dailyExtremes reduces the values of a section that begin within each
+// day using pick, returning NaN for days without any values.
+func (dwml *DWML) dailyExtremes(days []FireWeatherDay, accessor sectionAccessor, pick func(float64, float64) float64) ([]float64, string) {
+	extremes := make([]float64, len(days))
+
+	for i := range extremes {
+		extremes[i] = math.NaN()
+	}
+
+	periods, err := dwml.sectionPeriods(accessor)
+
+	if err != nil || len(periods) == 0 {
+		return extremes, ""
+	}
+
+	for i, day := range days {
+		end := day.TimeSpan.End
+
+		if !end.After(day.TimeSpan.Begin) {
+			end = day.TimeSpan.Begin.Add(24 * time.Hour)
+		}
+
+		for _, p := range periods {
+			if p.TimeSpan.Begin.Before(day.TimeSpan.Begin) || !p.TimeSpan.Begin.Before(end) {
+				continue
+			}
+
+			if math.IsNaN(extremes[i]) {
+				extremes[i] = p.Value
+			} else {
+				extremes[i] = pick(extremes[i], p.Value)
+			}
+		}
+	}
+
+	return extremes, periods[0].Units
+}
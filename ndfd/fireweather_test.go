@@ -0,0 +1,55 @@
+package ndfd
+
+import (
+	"testing"
+)
+
+func TestParseFireRisk(t *testing.T) {
+	tests := map[string]FireRisk{
+		"No Critical Fire Weather":        FireRiskNone,
+		"Elevated Fire Weather":           FireRiskElevated,
+		"Critical Fire Weather":           FireRiskCritical,
+		"Extremely Critical Fire Weather": FireRiskExtremelyCritical,
+		"bogus":                           FireRiskUnknown,
+	}
+
+	for s, expected := range tests {
+		if r := ParseFireRisk(s); r != expected {
+			t.Errorf("%q parsed as %s, expected %s", s, r, expected)
+		}
+	}
+
+	if r := ParseDryThunderstormRisk("Scattered Dry Thunderstorms"); r != DryThunderstormsScattered {
+		t.Errorf("Scattered dry thunderstorms parsed as %s", r)
+	}
+}
+
+func TestFireWeatherSummary(t *testing.T) {
+	dwml := loadDWML(t, "fire-weather.xml")
+
+	days, err := dwml.FireWeatherSummary()
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if len(days) != 2 {
+		t.Fatalf("%d fire weather days returned, but should have received 2", len(days))
+	}
+
+	first := days[0]
+
+	if first.Risk != FireRiskCritical || first.DryThunderstorms != DryThunderstormsNone {
+		t.Errorf("First day risks decoded as %+v", first)
+	}
+
+	if first.MinRelativeHumidity != 8 || first.MaxWindSpeed != 12 || first.MaxWindGust != 21 || first.WindSpeedUnits != "meters/second" {
+		t.Errorf("First day summarized as %+v", first)
+	}
+
+	second := days[1]
+
+	if second.Risk != FireRiskElevated || second.DryThunderstorms != DryThunderstormsIsolated || second.MinRelativeHumidity != 22 {
+		t.Errorf("Second day summarized as %+v", second)
+	}
+}
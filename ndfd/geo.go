@@ -0,0 +1,65 @@
+package ndfd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gershwinlabs/noaa/geo"
+	"net/http"
+)
+
+// Point returns where the condition applies.
+func (c Condition) Point() geo.Point {
+	return geo.Point{Lat: c.Lat, Lon: c.Lon}
+}
+
+func (p DataLocationPoint) Point() geo.Point {
+	return geo.Point{Lat: p.Latitude, Lon: p.Longitude}
+}
+
+// PointAt returns the selection of the single point p, or an error when
+// p is out of range.
+func PointAt(p geo.Point) (Point, error) {
+	if err := p.Validate(); err != nil {
+		return Point{}, err
+	}
+
+	return Point{p.Lat, p.Lon}, nil
+}
+
+// SubgridFor returns the selection of the grid points within box, every
+// resolution kilometers.
+func SubgridFor(box geo.BBox, resolution float64) Subgrid {
+	return Subgrid{Point{box.South, box.West}, Point{box.North, box.East}, resolution}
+}
+
+func FetchNDFDAt(p geo.Point) (NDFD, error) {
+	return FetchNDFDAtWithClient(http.DefaultClient, p)
+}
+
+func FetchNDFDAtWithClient(client *http.Client, p geo.Point) (NDFD, error) {
+	sel, err := PointAt(p)
+
+	if err != nil {
+		return NDFD{}, err
+	}
+
+	return FetchNDFDSelectionWithClient(client, sel)
+}
+
+// Nearest returns the location in the document closest to p, such as the
+// grid point of a subgrid that a place falls in.
+func (d Data) Nearest(p geo.Point) (DataLocation, error) {
+	if len(d.Locations) == 0 {
+		return DataLocation{}, errors.New(fmt.Sprintf("No locations near %s", p))
+	}
+
+	nearest := d.Locations[0]
+
+	for _, loc := range d.Locations[1:] {
+		if p.DistanceTo(loc.Point.Point()) < p.DistanceTo(nearest.Point.Point()) {
+			nearest = loc
+		}
+	}
+
+	return nearest, nil
+}
@@ -0,0 +1,48 @@
+package ndfd
+
+import (
+	"github.com/gershwinlabs/noaa/geo"
+	"testing"
+)
+
+func TestNearest(t *testing.T) {
+	dwml := loadDWML(t, "multi-point.xml")
+	loc, err := dwml.Data.Nearest(geo.Point{Lat: 39.74, Lon: -104.99})
+
+	if err != nil || loc.LocationKey != "point2" {
+		t.Errorf("Nearest location %+v %v", loc, err)
+	}
+
+	if p := loc.Point.Point(); p.Lat != 39.70 || p.Lon != -104.80 {
+		t.Errorf("Location at %+v", p)
+	}
+
+	if _, err := (Data{}).Nearest(geo.Point{}); err == nil {
+		t.Errorf("Found a location in an empty document")
+	}
+}
+
+func TestGeoSelections(t *testing.T) {
+	if _, err := PointAt(geo.Point{Lat: 91, Lon: 0}); err == nil {
+		t.Errorf("Selected a point beyond the pole")
+	}
+
+	sel, err := PointAt(geo.Point{Lat: 39.64, Lon: -106.37})
+
+	if err != nil || sel != (Point{39.64, -106.37}) {
+		t.Errorf("Selected %+v %v", sel, err)
+	}
+
+	sg := SubgridFor(geo.NewBBox(geo.Point{Lat: 40, Lon: -105}, geo.Point{Lat: 39, Lon: -106}), 20)
+	q := sg.query()
+
+	if q.Get("lat1") != "39.000000" || q.Get("lon1") != "-106.000000" || q.Get("lat2") != "40.000000" || q.Get("lon2") != "-105.000000" {
+		t.Errorf("Subgrid query %v", q)
+	}
+
+	c := Condition{Lat: 39.64, Lon: -106.37}
+
+	if c.Point() != (geo.Point{Lat: 39.64, Lon: -106.37}) {
+		t.Errorf("Condition at %+v", c.Point())
+	}
+}
@@ -0,0 +1,257 @@
+package ndfd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gershwinlabs/noaa"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Icon is a decoded NWS conditions icon link such as
+// http://forecast.weather.gov/images/wtf/medium/nra60.png, which
+// describes night time rain with a 60% probability of precipitation.
+type Icon struct {
+	Link  string
+	Code  string
+	Night bool
+	PoP   int
+}
+
+type IconPeriod struct {
+	TimeSpan noaa.TimeSpan
+	Icon     Icon
+}
+
+type TextPeriod struct {
+	TimeSpan noaa.TimeSpan
+	Text     string
+}
+
+// iconCodes holds the daytime form of the icon codes in use by the NWS.
+// Night icons carry the same code with an "n" prefix.
+var iconCodes = map[string]string{
+	"skc":       "Clear",
+	"few":       "A Few Clouds",
+	"sct":       "Partly Cloudy",
+	"bkn":       "Mostly Cloudy",
+	"ovc":       "Overcast",
+	"wind":      "Windy",
+	"wind_skc":  "Clear and Windy",
+	"wind_few":  "A Few Clouds and Windy",
+	"wind_sct":  "Partly Cloudy and Windy",
+	"wind_bkn":  "Mostly Cloudy and Windy",
+	"wind_ovc":  "Overcast and Windy",
+	"fg":        "Fog",
+	"sctfg":     "Patchy Fog",
+	"hz":        "Haze",
+	"fu":        "Smoke",
+	"du":        "Dust",
+	"ra":        "Rain",
+	"minus_ra":  "Light Rain",
+	"shra":      "Rain Showers",
+	"hi_shwrs":  "Isolated Rain Showers",
+	"sn":        "Snow",
+	"ra_sn":     "Rain and Snow",
+	"rasn":      "Rain and Snow",
+	"raip":      "Rain and Sleet",
+	"ip":        "Sleet",
+	"snip":      "Snow and Sleet",
+	"fzra":      "Freezing Rain",
+	"ra_fzra":   "Rain and Freezing Rain",
+	"fzra_sn":   "Freezing Rain and Snow",
+	"mix":       "Wintry Mix",
+	"blizzard":  "Blizzard",
+	"tsra":      "Thunderstorms",
+	"scttsra":   "Scattered Thunderstorms",
+	"hi_tsra":   "Isolated Thunderstorms",
+	"svrtsra":   "Severe Thunderstorms",
+	"fc":        "Funnel Cloud",
+	"tor":       "Tornado",
+	"hur_warn":  "Hurricane Warning",
+	"hur_watch": "Hurricane Watch",
+	"ts_warn":   "Tropical Storm Warning",
+	"ts_watch":  "Tropical Storm Watch",
+	"ts_nowarn": "Tropical Storm",
+	"hot":       "Hot",
+	"cold":      "Cold",
+}
+
+func ParseIconLink(link string) (Icon, error) {
+	icon := Icon{Link: link}
+
+	if link == "" {
+		return icon, errors.New("Empty icon link")
+	}
+
+	u, err := url.Parse(link)
+
+	if err != nil {
+		return icon, err
+	}
+
+	name := path.Base(u.Path)
+	name = strings.TrimSuffix(name, path.Ext(name))
+	code := strings.TrimRight(name, "0123456789")
+
+	if code == "" || code == "." || code == "/" {
+		return icon, errors.New(fmt.Sprintf("Could not find an icon code in %s", link))
+	}
+
+	if len(code) < len(name) {
+		pop, err := strconv.Atoi(name[len(code):])
+
+		if err != nil || pop > 100 {
+			return icon, errors.New(fmt.Sprintf("Invalid probability of precipitation in %s", link))
+		}
+
+		icon.PoP = pop
+	}
+
+	if _, ok := iconCodes[code]; !ok {
+		if strings.HasPrefix(code, "hi_n") {
+			if _, ok := iconCodes["hi_"+code[4:]]; ok {
+				code = "hi_" + code[4:]
+				icon.Night = true
+			}
+		} else if strings.HasPrefix(code, "n") {
+			if _, ok := iconCodes[code[1:]]; ok {
+				code = code[1:]
+				icon.Night = true
+			}
+		}
+	}
+
+	icon.Code = code
+	return icon, nil
+}
+
+func (icon Icon) Description() string {
+	desc, ok := iconCodes[icon.Code]
+
+	if !ok {
+		return icon.Code
+	}
+
+	return desc
+}
+
+func (dp DataParameters) ConditionsIcons() (string, []Icon, error) {
+	if dp.ConditionsIcon.TimeLayout == "" {
+		return "unknown", []Icon{}, errors.New("Could not find conditions icons")
+	}
+
+	icons := make([]Icon, len(dp.ConditionsIcon.IconLink))
+
+	for i, link := range dp.ConditionsIcon.IconLink {
+		icon, err := ParseIconLink(strings.TrimSpace(link))
+
+		if err != nil {
+			icon = Icon{Link: link}
+		}
+
+		icons[i] = icon
+	}
+
+	return dp.ConditionsIcon.TimeLayout, icons, nil
+}
+
+func (dp DataParameters) WeatherSummaries() (string, []string, error) {
+	if dp.Weathers.TimeLayout == "" {
+		return "unknown", []string{}, errors.New("Could not find weather summaries")
+	}
+
+	summaries := make([]string, len(dp.Weathers.WeatherConditions))
+
+	for i, wc := range dp.Weathers.WeatherConditions {
+		summaries[i] = wc.WeatherSummary
+	}
+
+	return dp.Weathers.TimeLayout, summaries, nil
+}
+
+func (dp DataParameters) WordedForecasts() (string, []string, error) {
+	if dp.WordedForecast.TimeLayout == "" {
+		return "unknown", []string{}, errors.New("Could not find worded forecast")
+	}
+
+	texts := make([]string, len(dp.WordedForecast.Texts))
+
+	for i, text := range dp.WordedForecast.Texts {
+		texts[i] = strings.TrimSpace(text)
+	}
+
+	return dp.WordedForecast.TimeLayout, texts, nil
+}
+
+func (dwml *DWML) IconPeriods() ([]IconPeriod, error) {
+	dp, err := dwml.Data.singleParameters()
+
+	if err != nil {
+		return []IconPeriod{}, err
+	}
+
+	layout, icons, err := dp.ConditionsIcons()
+
+	if err != nil {
+		return []IconPeriod{}, err
+	}
+
+	spans, err := dwml.layoutTimeSpans(layout)
+
+	if err != nil {
+		return []IconPeriod{}, err
+	}
+
+	periods := make([]IconPeriod, 0, len(icons))
+
+	for i, icon := range icons {
+		if i >= len(spans) {
+			break
+		}
+
+		periods = append(periods, IconPeriod{spans[i], icon})
+	}
+
+	return periods, nil
+}
+
+// TextForecast returns the worded forecast when one is present, and
+// falls back to the weather summaries otherwise.
+func (dwml *DWML) TextForecast() ([]TextPeriod, error) {
+	dp, err := dwml.Data.singleParameters()
+
+	if err != nil {
+		return []TextPeriod{}, err
+	}
+
+	layout, texts, err := dp.WordedForecasts()
+
+	if err != nil {
+		layout, texts, err = dp.WeatherSummaries()
+
+		if err != nil {
+			return []TextPeriod{}, err
+		}
+	}
+
+	spans, err := dwml.layoutTimeSpans(layout)
+
+	if err != nil {
+		return []TextPeriod{}, err
+	}
+
+	periods := make([]TextPeriod, 0, len(texts))
+
+	for i, text := range texts {
+		if i >= len(spans) {
+			break
+		}
+
+		periods = append(periods, TextPeriod{spans[i], text})
+	}
+
+	return periods, nil
+}
@@ -0,0 +1,73 @@
+package ndfd
+
+import (
+	"testing"
+)
+
+func TestParseIconLink(t *testing.T) {
+	tests := []struct {
+		link  string
+		code  string
+		night bool
+		pop   int
+	}{
+		{"http://forecast.weather.gov/images/wtf/medium/skc.png", "skc", false, 0},
+		{"http://forecast.weather.gov/images/wtf/medium/nra60.png", "ra", true, 60},
+		{"http://forecast.weather.gov/images/wtf/medium/hi_ntsra20.png", "hi_tsra", true, 20},
+		{"http://forecast.weather.gov/newimages/medium/ra_sn100.png", "ra_sn", false, 100},
+		{"http://forecast.weather.gov/images/wtf/medium/wind_bkn.jpg", "wind_bkn", false, 0},
+	}
+
+	for _, test := range tests {
+		icon, err := ParseIconLink(test.link)
+
+		if err != nil {
+			t.Errorf("%s", err)
+			continue
+		}
+
+		if icon.Code != test.code || icon.Night != test.night || icon.PoP != test.pop {
+			t.Errorf("%s parsed as %+v", test.link, icon)
+		}
+	}
+
+	if _, err := ParseIconLink(""); err == nil {
+		t.Errorf("Empty icon link should not parse")
+	}
+
+	if _, err := ParseIconLink("http://forecast.weather.gov/images/wtf/medium/ra150.png"); err == nil {
+		t.Errorf("PoP over 100 should not parse")
+	}
+}
+
+func TestIconPeriods(t *testing.T) {
+	dwml := loadDWML(t, "conditions-icons.xml")
+
+	periods, err := dwml.IconPeriods()
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if len(periods) != 3 {
+		t.Fatalf("%d icon periods returned, but should have received 3", len(periods))
+	}
+
+	if periods[1].Icon.Code != "sn" || !periods[1].Icon.Night || periods[1].Icon.PoP != 40 {
+		t.Errorf("Second icon period decoded as %+v", periods[1].Icon)
+	}
+
+	if periods[1].TimeSpan.Begin.Hour() != 18 {
+		t.Errorf("Second icon period begins at %v", periods[1].TimeSpan.Begin)
+	}
+
+	text, err := dwml.TextForecast()
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if len(text) != 3 || text[1].Text != "Chance Snow Showers" {
+		t.Errorf("Text forecast decoded as %+v", text)
+	}
+}
@@ -1,34 +1,52 @@
 package ndfd
 
 import (
+	"context"
 	"encoding/xml"
 	"errors"
 	"fmt"
 	"github.com/gershwinlabs/noaa"
+	"github.com/gershwinlabs/noaa/units"
+	"io"
 	"math"
 	"net/http"
-	"net/url"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 )
 
 const (
-	sourceURLFormat = "http://graphical.weather.gov/xml/sample_products/browser_interface/ndfdXMLclient.php?whichClient=NDFDgen&lat=%f&lon=%f&product=time-series&begin=%s&end=%s&Unit=m&maxt=maxt&mint=mint&temp=temp&qpf=qpf&pop12=pop12&snow=snow&dew=dew&wspd=wspd&wdir=wdir&sky=sky&wx=wx&waveh=waveh&icons=icons&rh=rh&appt=appt&incw34=incw34&incw50=incw50&incw64=incw64&cumw34=cumw34&cumw50=cumw50&cumw64=cumw64&critfireo=critfireo&dryfireo=dryfireo&conhazo=conhazo&ptornado=ptornado&phail=phail&ptstmwinds=ptstmwinds&pxtornado=pxtornado&pxhail=pxhail&pxtstmwinds=pxtstmwinds&ptotsvrtstm=ptotsvrtstm&pxtotsvrtstm=pxtotsvrtstm&tmpabv14d=tmpabv14d&tmpblw14d=tmpblw14d&tmpabv30d=tmpabv30d&tmpblw30d=tmpblw30d&tmpabv90d=tmpabv90d&tmpblw90d=tmpblw90d&prcpabv14d=prcpabv14d&prcpblw14d=prcpblw14d&prcpabv30d=prcpabv30d&prcpblw30d=prcpblw30d&prcpabv90d=prcpabv90d&prcpblw90d=prcpblw90d&precipa_r=precipa_r&sky_r=sky_r&td_r=td_r&temp_r=temp_r&wdir_r=wdir_r&wspd_r=wspd_r&wwa=wwa&wgust=wgust&iceaccum=iceaccum&maxrh=maxrh&minrh=minrh&Submit=Submit"
+	sourceURLBase = "http://graphical.weather.gov/xml/sample_products/browser_interface/ndfdXMLclient.php"
+	elementQuery  = "maxt=maxt&mint=mint&temp=temp&qpf=qpf&pop12=pop12&snow=snow&dew=dew&wspd=wspd&wdir=wdir&sky=sky&wx=wx&waveh=waveh&icons=icons&rh=rh&appt=appt&incw34=incw34&incw50=incw50&incw64=incw64&cumw34=cumw34&cumw50=cumw50&cumw64=cumw64&critfireo=critfireo&dryfireo=dryfireo&conhazo=conhazo&ptornado=ptornado&phail=phail&ptstmwinds=ptstmwinds&pxtornado=pxtornado&pxhail=pxhail&pxtstmwinds=pxtstmwinds&ptotsvrtstm=ptotsvrtstm&pxtotsvrtstm=pxtotsvrtstm&tmpabv14d=tmpabv14d&tmpblw14d=tmpblw14d&tmpabv30d=tmpabv30d&tmpblw30d=tmpblw30d&tmpabv90d=tmpabv90d&tmpblw90d=tmpblw90d&prcpabv14d=prcpabv14d&prcpblw14d=prcpblw14d&prcpabv30d=prcpabv30d&prcpblw30d=prcpblw30d&prcpabv90d=prcpabv90d&prcpblw90d=prcpblw90d&precipa_r=precipa_r&sky_r=sky_r&td_r=td_r&temp_r=temp_r&wdir_r=wdir_r&wspd_r=wspd_r&wwa=wwa&wgust=wgust&iceaccum=iceaccum&maxrh=maxrh&minrh=minrh&Submit=Submit"
 )
 
+// NDFD is a decoded forecast.  CreationDate is when the forecast was
+// issued, or zero if the document did not say.  Conditions holds every
+// element whose time layout could be parsed, and LayoutErrors records
+// the ones that could not.
 type NDFD struct {
-	SourceURL  string
-	Dwml       *DWML
-	Conditions chan Condition
+	SourceURL    string
+	CreationDate time.Time
+	Dwml         *DWML
+	Conditions   *Table
+	LayoutErrors LayoutErrors
+}
+
+type ValuePeriod struct {
+	TimeSpan noaa.TimeSpan
+	Value    float64
+	Units    string
 }
 
 type Condition struct {
-	Name  string
-	Value float64
-	Units string
-	Hour  time.Time
-	Lat   float64
-	Lon   float64
+	Name     string
+	Value    float64
+	Units    string
+	Hour     time.Time
+	Lat      float64
+	Lon      float64
+	Location string
 }
 
 func FetchNDFD(lat, lon float64) (NDFD, error) {
@@ -62,9 +80,25 @@ func FetchNDFDForecastWithClient(client *http.Client, lat, lon float64) (NDFD, e
 }
 
 func FetchNDFDWithClientForTimeSpan(client *http.Client, ts noaa.TimeSpan, lat, lon float64) (NDFD, error) {
-	b := url.QueryEscape(ts.Begin.Format("2006-01-02T15:04:05"))
-	e := url.QueryEscape(ts.End.Format("2006-01-02T15:04:05"))
-	sourceURL := fmt.Sprintf(sourceURLFormat, lat, lon, b, e)
+	return FetchNDFDSelectionWithClientForTimeSpan(client, ts, Point{lat, lon})
+}
+
+func FetchNDFDSelection(sel Selection) (NDFD, error) {
+	return FetchNDFDSelectionWithClient(http.DefaultClient, sel)
+}
+
+func FetchNDFDSelectionWithClient(client *http.Client, sel Selection) (NDFD, error) {
+	b := time.Now().UTC().Add(time.Duration(-10*24) * time.Hour)
+	e := time.Now().UTC().Add(time.Duration(10*24) * time.Hour)
+	return FetchNDFDSelectionWithClientForTimeSpan(client, noaa.TimeSpan{Begin: b, End: e}, sel)
+}
+
+func FetchNDFDSelectionWithClientForTimeSpan(client *http.Client, ts noaa.TimeSpan, sel Selection) (NDFD, error) {
+	return FetchRequestWithClient(client, Request{sel, ts, units.Metric})
+}
+
+func FetchRequestWithClient(client *http.Client, r Request) (NDFD, error) {
+	sourceURL := r.URL()
 	resp, err := client.Get(sourceURL)
 
 	if err != nil {
@@ -75,26 +109,51 @@ func FetchNDFDWithClientForTimeSpan(client *http.Client, ts noaa.TimeSpan, lat,
 }
 
 func processNDFDResponse(resp *http.Response, sourceURL string) (NDFD, error) {
+	dwml, err := decodeDWMLResponse(resp, sourceURL)
+
+	if err != nil {
+		return NDFD{}, err
+	}
+
+	created, _ := dwml.CreationDate()
+	return NDFD{sourceURL, created, dwml, dwml.Table(), dwml.TimeLayoutErrors()}, nil
+}
+
+func decodeDWMLResponse(resp *http.Response, sourceURL string) (*DWML, error) {
+	defer resp.Body.Close()
+
 	if resp.StatusCode != 200 {
-		return NDFD{}, errors.New(fmt.Sprintf("Received error %d from %s", resp.StatusCode, sourceURL))
+		return nil, errors.New(fmt.Sprintf("Received error %d from %s", resp.StatusCode, sourceURL))
 	}
 
+	return ParseDWML(resp.Body)
+}
+
+// ParseDWML decodes a DWML document from r, such as a file archived
+// from an earlier request.
+func ParseDWML(r io.Reader) (*DWML, error) {
 	var dwml DWML
-	decoder := xml.NewDecoder(resp.Body)
-	defer resp.Body.Close()
+	decoder := xml.NewDecoder(r)
 	err := decoder.Decode(&dwml)
 
 	if err != nil {
-		return NDFD{}, err
+		return nil, err
 	}
 
-	condChan, err := dwml.collectConditions()
+	return &dwml, nil
+}
+
+// ParseNDFD decodes a DWML document from r along with its Conditions.
+// The SourceURL of the result is empty.
+func ParseNDFD(r io.Reader) (NDFD, error) {
+	dwml, err := ParseDWML(r)
 
 	if err != nil {
 		return NDFD{}, err
 	}
 
-	return NDFD{sourceURL, &dwml, condChan}, nil
+	created, _ := dwml.CreationDate()
+	return NDFD{"", created, dwml, dwml.Table(), dwml.TimeLayoutErrors()}, nil
 }
 
 type DWML struct {
@@ -102,154 +161,315 @@ type DWML struct {
 	Data Data `xml:"data"`
 }
 
-func (dwml *DWML) generateTimeSpanLayoutMap() (map[string][]noaa.TimeSpan, error) {
-	m := make(map[string][]noaa.TimeSpan)
+// CreationDate returns when the forecast in the document was issued.
+func (dwml *DWML) CreationDate() (time.Time, error) {
+	return time.Parse(time.RFC3339, strings.TrimSpace(dwml.Head.Product.CreationDate.Value))
+}
 
-	for _, timeLayout := range dwml.Data.TimeLayouts {
-		numStartTimes := len(timeLayout.StartValidTimes)
-		numEndTimes := len(timeLayout.EndValidTimes)
-		arr := make([]noaa.TimeSpan, numStartTimes)
+// LayoutErrors holds the errors for time layouts that could not be
+// parsed, by layout key.  Elements using the other layouts in the
+// document are still decoded.
+type LayoutErrors map[string]error
 
-		for i := 0; i < numStartTimes; i++ {
-			begin, err := time.ParseInLocation(time.RFC3339, timeLayout.StartValidTimes[i], time.UTC)
+func (le LayoutErrors) Error() string {
+	keys := make([]string, 0, len(le))
 
-			if err != nil {
-				return m, err
-			}
+	for key := range le {
+		keys = append(keys, key)
+	}
 
-			end := begin
+	sort.Strings(keys)
+	msgs := make([]string, len(keys))
 
-			if numEndTimes == numStartTimes {
-				end, err = time.ParseInLocation(time.RFC3339, timeLayout.EndValidTimes[i], time.UTC)
+	for i, key := range keys {
+		msgs[i] = fmt.Sprintf("time layout %s: %s", key, le[key])
+	}
 
-				if err != nil {
-					return m, err
-				}
+	return strings.Join(msgs, "; ")
+}
+
+// TimeLayoutErrors returns the errors for any time layouts in the
+// document that could not be parsed, or nil when all of them were.
+func (dwml *DWML) TimeLayoutErrors() LayoutErrors {
+	_, errs := dwml.generateTimeSpanLayoutMap()
+	return errs
+}
+
+func (dwml *DWML) generateTimeSpanLayoutMap() (map[string][]noaa.TimeSpan, LayoutErrors) {
+	m := make(map[string][]noaa.TimeSpan)
+	var errs LayoutErrors
+
+	for _, timeLayout := range dwml.Data.TimeLayouts {
+		spans, err := timeLayout.timeSpans()
+
+		if err != nil {
+			if errs == nil {
+				errs = make(LayoutErrors)
 			}
 
-			arr[i] = noaa.TimeSpan{begin, end}
+			errs[timeLayout.LayoutKey] = err
+			continue
 		}
 
-		m[timeLayout.LayoutKey] = arr
+		m[timeLayout.LayoutKey] = spans
 	}
 
-	return m, nil
+	return m, errs
 }
 
-func (dwml *DWML) collectConditions() (chan Condition, error) {
-	tsMap, err := dwml.generateTimeSpanLayoutMap()
-	condChan := make(chan Condition, 10)
+func (timeLayout DataTimeLayout) timeSpans() ([]noaa.TimeSpan, error) {
+	numStartTimes := len(timeLayout.StartValidTimes)
+	numEndTimes := len(timeLayout.EndValidTimes)
+	arr := make([]noaa.TimeSpan, numStartTimes)
 
-	if err != nil {
-		return condChan, err
-	}
+	for i := 0; i < numStartTimes; i++ {
+		begin, err := parseValidTime(timeLayout.StartValidTimes[i], timeLayout.TimeCoordinate)
 
-	go func() {
-		lat := dwml.Data.Location.Point.Latitude
-		lon := dwml.Data.Location.Point.Longitude
+		if err != nil {
+			return arr, err
+		}
 
-		layout, units, vals, err := dwml.Data.Parameters.HourlyTemperatures()
+		end := begin
 
-		if err == nil {
-			for i, val := range vals {
-				if math.IsNaN(val) {
-					continue
-				}
+		if numEndTimes == numStartTimes {
+			end, err = parseValidTime(timeLayout.EndValidTimes[i], timeLayout.TimeCoordinate)
 
-				for _, hour := range tsMap[layout][i].Hours() {
-					condChan <- Condition{"temp", val, units, hour, lat, lon}
-				}
+			if err != nil {
+				return arr, err
 			}
 		}
 
-		layout, units, vals, err = dwml.Data.Parameters.HourlyDewPoints()
+		arr[i] = noaa.TimeSpan{Begin: begin, End: end}
+	}
 
-		if err == nil {
-			for i, val := range vals {
-				if math.IsNaN(val) {
-					continue
-				}
+	return arr, nil
+}
 
-				for _, hour := range tsMap[layout][i].Hours() {
-					condChan <- Condition{"dewpoint", val, units, hour, lat, lon}
-				}
-			}
-		}
+// parseValidTime parses a start or end-valid-time, keeping its offset.
+// Times without an offset are only accepted in UTC layouts, since the
+// zone of a local layout is not recorded anywhere else in the document.
+func parseValidTime(s, timeCoordinate string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	t, err := time.Parse(time.RFC3339, s)
+
+	if err == nil {
+		return t, nil
+	}
 
-		layout, units, vals, err = dwml.Data.Parameters.HourlyCloudAmounts()
+	if strings.EqualFold(timeCoordinate, "UTC") {
+		t, err = time.ParseInLocation("2006-01-02T15:04:05", s, time.UTC)
 
 		if err == nil {
-			for i, val := range vals {
-				if math.IsNaN(val) {
-					continue
-				}
+			return t, nil
+		}
+	}
 
-				for _, hour := range tsMap[layout][i].Hours() {
-					condChan <- Condition{"clouds", val, units, hour, lat, lon}
-				}
-			}
+	return t, errors.New(fmt.Sprintf("Could not parse %s time %q", timeCoordinate, s))
+}
+
+type sectionAccessor func(DataParameters) (string, string, []float64, error)
+
+var conditionSections = []struct {
+	name     string
+	accessor sectionAccessor
+}{
+	{"temp", DataParameters.HourlyTemperatures},
+	{"dewpoint", DataParameters.HourlyDewPoints},
+	{"maxt", DataParameters.DailyMaximumTemperatures},
+	{"mint", DataParameters.DailyMinimumTemperatures},
+	{"pop12", DataParameters.TwelveHourPoPs},
+	{"clouds", DataParameters.HourlyCloudAmounts},
+	{"precip", DataParameters.HourlyLiquidPrecip},
+	{"windspeed", DataParameters.HourlyWindSpeeds},
+	{"winddir", DataParameters.HourlyWindDirections},
+	{"snow", DataParameters.HourlySnowAmounts},
+	{"rh", DataParameters.HourlyRelativeHumidities},
+	{"wgust", DataParameters.HourlyWindGusts},
+	{"conhazo", DataParameters.convectiveOutlookValues},
+	{"ptornado", DataParameters.TornadoProbabilities},
+	{"phail", DataParameters.HailProbabilities},
+	{"ptstmwinds", DataParameters.DamagingWindProbabilities},
+	{"pxtornado", DataParameters.ExtremeTornadoProbabilities},
+	{"pxhail", DataParameters.ExtremeHailProbabilities},
+	{"pxtstmwinds", DataParameters.ExtremeWindProbabilities},
+	{"ptotsvrtstm", DataParameters.SevereThunderstormProbabilities},
+	{"pxtotsvrtstm", DataParameters.ExtremeSevereThunderstormProbabilities},
+	{"incw34", DataParameters.Incremental34KnotWindProbabilities},
+	{"incw50", DataParameters.Incremental50KnotWindProbabilities},
+	{"incw64", DataParameters.Incremental64KnotWindProbabilities},
+	{"cumw34", DataParameters.Cumulative34KnotWindProbabilities},
+	{"cumw50", DataParameters.Cumulative50KnotWindProbabilities},
+	{"cumw64", DataParameters.Cumulative64KnotWindProbabilities},
+	{"critfireo", DataParameters.fireRiskValues},
+	{"dryfireo", DataParameters.dryThunderstormRiskValues},
+}
+
+// Conditions returns a channel of the hourly Conditions for every
+// location in the document.  Each call starts a new channel.  Elements
+// whose time layout could not be parsed are skipped; see
+// TimeLayoutErrors.  The channel must be drained; use ConditionsContext
+// to stop reading early.
+func (dwml *DWML) Conditions() (chan Condition, error) {
+	return dwml.collectConditions(context.Background())
+}
+
+// ConditionsContext is like Conditions, but stops sending and closes the
+// channel once ctx is cancelled.
+func (dwml *DWML) ConditionsContext(ctx context.Context) (chan Condition, error) {
+	return dwml.collectConditions(ctx)
+}
+
+// collectConditions returns an error, and a closed channel, when any
+// parameters apply to a location missing from the document.
+func (dwml *DWML) collectConditions(ctx context.Context) (chan Condition, error) {
+	tsMap, _ := dwml.generateTimeSpanLayoutMap()
+	condChan := make(chan Condition, 10)
+	locs := make([]DataLocation, len(dwml.Data.Parameters))
+
+	for i, dp := range dwml.Data.Parameters {
+		loc, err := dwml.Data.applicableLocation(dp.ApplicableLocation)
+
+		if err != nil {
+			close(condChan)
+			return condChan, err
 		}
 
-		layout, units, vals, err = dwml.Data.Parameters.HourlyLiquidPrecip()
+		locs[i] = loc
+	}
 
-		if err == nil {
-			for i, val := range vals {
-				if math.IsNaN(val) {
-					continue
+	go func() {
+		defer close(condChan)
+
+		for i, dp := range dwml.Data.Parameters {
+			sent := parameterConditions(dp, locs[i], tsMap, func(c Condition) bool {
+				select {
+				case condChan <- c:
+					return true
+				case <-ctx.Done():
+					return false
 				}
+			})
 
-				for _, hour := range tsMap[layout][i].Hours() {
-					condChan <- Condition{"precip", val, units, hour, lat, lon}
-				}
+			if !sent {
+				return
 			}
 		}
+	}()
 
-		layout, units, vals, err = dwml.Data.Parameters.HourlyWindSpeeds()
+	return condChan, nil
+}
 
-		if err == nil {
-			for i, val := range vals {
-				if math.IsNaN(val) {
-					continue
-				}
+// parameterConditions calls send with each hourly Condition in dp,
+// stopping early if send returns false.
+func parameterConditions(dp DataParameters, loc DataLocation, tsMap map[string][]noaa.TimeSpan, send func(Condition) bool) bool {
+	lat := loc.Point.Latitude
+	lon := loc.Point.Longitude
 
-				for _, hour := range tsMap[layout][i].Hours() {
-					condChan <- Condition{"windspeed", val, units, hour, lat, lon}
-				}
-			}
+	for _, section := range conditionSections {
+		layout, units, vals, err := section.accessor(dp)
+
+		if err != nil {
+			continue
 		}
 
-		layout, units, vals, err = dwml.Data.Parameters.HourlyWindDirections()
+		spans := tsMap[layout]
 
-		if err == nil {
-			for i, val := range vals {
-				if math.IsNaN(val) {
-					continue
-				}
+		for i, val := range vals {
+			if math.IsNaN(val) || i >= len(spans) {
+				continue
+			}
 
-				for _, hour := range tsMap[layout][i].Hours() {
-					condChan <- Condition{"winddir", val, units, hour, lat, lon}
+			for _, hour := range spans[i].Hours() {
+				if !send(Condition{section.name, val, units, hour, lat, lon, loc.LocationKey}) {
+					return false
 				}
 			}
 		}
+	}
 
-		layout, units, vals, err = dwml.Data.Parameters.HourlySnowAmounts()
+	return true
+}
 
-		if err == nil {
-			for i, val := range vals {
-				if math.IsNaN(val) {
-					continue
-				}
+func (dwml *DWML) layoutTimeSpans(layout string) ([]noaa.TimeSpan, error) {
+	tsMap, errs := dwml.generateTimeSpanLayoutMap()
 
-				for _, hour := range tsMap[layout][i].Hours() {
-					condChan <- Condition{"snow", val, units, hour, lat, lon}
-				}
-			}
+	if err, ok := errs[layout]; ok {
+		return []noaa.TimeSpan{}, err
+	}
+
+	spans, ok := tsMap[layout]
+
+	if !ok {
+		return []noaa.TimeSpan{}, errors.New(fmt.Sprintf("Could not find time layout %s", layout))
+	}
+
+	return spans, nil
+}
+
+func (dwml *DWML) sectionPeriods(accessor sectionAccessor) ([]ValuePeriod, error) {
+	dp, err := dwml.Data.singleParameters()
+
+	if err != nil {
+		return []ValuePeriod{}, err
+	}
+
+	return dwml.parameterPeriods(dp, accessor)
+}
+
+func (dwml *DWML) parameterPeriods(dp DataParameters, accessor sectionAccessor) ([]ValuePeriod, error) {
+	layout, units, vals, err := accessor(dp)
+
+	if err != nil {
+		return []ValuePeriod{}, err
+	}
+
+	spans, err := dwml.layoutTimeSpans(layout)
+
+	if err != nil {
+		return []ValuePeriod{}, err
+	}
+
+	periods := make([]ValuePeriod, 0, len(vals))
+
+	for i, val := range vals {
+		if math.IsNaN(val) || i >= len(spans) {
+			continue
 		}
 
-		close(condChan)
-	}()
+		periods = append(periods, ValuePeriod{spans[i], val, units})
+	}
 
-	return condChan, nil
+	return periods, nil
+}
+
+// ForLocation returns a copy of the document holding only the location
+// and parameters for key, so that the single point accessors can be
+// used on multiple point documents.
+func (dwml *DWML) ForLocation(key string) (*DWML, error) {
+	loc, err := dwml.Data.Location(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	dp, err := dwml.Data.ParametersFor(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d := &DWML{Head: dwml.Head}
+	d.Data.Locations = []DataLocation{loc}
+	d.Data.TimeLayouts = dwml.Data.TimeLayouts
+	d.Data.Parameters = []DataParameters{dp}
+
+	for _, mwi := range dwml.Data.MoreWeatherInformation {
+		if mwi.ApplicableLocation == key {
+			d.Data.MoreWeatherInformation = append(d.Data.MoreWeatherInformation, mwi)
+		}
+	}
+
+	return d, nil
 }
 
 type Head struct {
@@ -268,7 +488,7 @@ type HeadProduct struct {
 }
 
 type HeadProductCreationDate struct {
-	RefreshFreq string `xml:"refresh-frequency,attr"`
+	RefreshFreq string `xml:"refresh-frequency,attr,omitempty"`
 	Value       string `xml:",chardata"`
 }
 
@@ -287,15 +507,55 @@ type HeadSourceProductionCenter struct {
 }
 
 type Data struct {
-	Location               DataLocation               `xml:"location"`
-	MoreWeatherInformation DataMoreWeatherInformation `xml:"moreWeatherInformation"`
-	TimeLayouts            []DataTimeLayout           `xml:"time-layout"`
-	Parameters             DataParameters             `xml:"parameters"`
+	Locations              []DataLocation               `xml:"location"`
+	MoreWeatherInformation []DataMoreWeatherInformation `xml:"moreWeatherInformation"`
+	TimeLayouts            []DataTimeLayout             `xml:"time-layout"`
+	Parameters             []DataParameters             `xml:"parameters"`
+}
+
+func (d Data) Location(key string) (DataLocation, error) {
+	for _, loc := range d.Locations {
+		if loc.LocationKey == key {
+			return loc, nil
+		}
+	}
+
+	return DataLocation{}, errors.New(fmt.Sprintf("Could not find location %s", key))
+}
+
+func (d Data) ParametersFor(key string) (DataParameters, error) {
+	for _, dp := range d.Parameters {
+		if dp.ApplicableLocation == key {
+			return dp, nil
+		}
+	}
+
+	return DataParameters{}, errors.New(fmt.Sprintf("Could not find parameters for location %s", key))
+}
+
+// applicableLocation falls back to the only location in the document
+// when parameters do not name the location they apply to.
+func (d Data) applicableLocation(key string) (DataLocation, error) {
+	if key == "" && len(d.Locations) == 1 {
+		return d.Locations[0], nil
+	}
+
+	return d.Location(key)
+}
+
+func (d Data) singleParameters() (DataParameters, error) {
+	if len(d.Parameters) != 1 {
+		return DataParameters{}, errors.New(fmt.Sprintf("Expected parameters for one location, found %d", len(d.Parameters)))
+	}
+
+	return d.Parameters[0], nil
 }
 
 type DataLocation struct {
 	LocationKey string            `xml:"location-key"`
+	Description string            `xml:"description,omitempty"`
 	Point       DataLocationPoint `xml:"point"`
+	City        *DataLocationCity `xml:"city,omitempty"`
 }
 
 type DataLocationPoint struct {
@@ -303,6 +563,11 @@ type DataLocationPoint struct {
 	Longitude float64 `xml:"longitude,attr"`
 }
 
+type DataLocationCity struct {
+	State string `xml:"state,attr"`
+	Value string `xml:",chardata"`
+}
+
 type DataMoreWeatherInformation struct {
 	ApplicableLocation string `xml:"applicable-location,attr"`
 	Value              string `xml:",chardata"`
@@ -320,44 +585,59 @@ type DataParameters struct {
 	ApplicableLocation           string                           `xml:"applicable-location,attr"`
 	Temperatures                 []DataParametersSection          `xml:"temperature"`
 	Precipitations               []DataParametersSection          `xml:"precipitation"`
-	WindSpeeds                   []DataParametersSection          `xml:"wind-speed"`
-	Directions                   []DataParametersSection          `xml:"direction"`
-	CloudAmounts                 []DataParametersSection          `xml:"cloud-amount"`
 	ProbabilitiesOfPrecipitation []DataParametersSection          `xml:"probability-of-precipitation"`
 	FireWeathers                 []DataParametersSection          `xml:"fire-weather"`
 	ConvectiveHazards            []DataParametersConvectiveHazard `xml:"convective-hazard"`
-	ClimateAnomalies             []DataParametersClimateAnomaly   `xml:"climate-anomoly"`
+	ClimateAnomalies             []DataParametersClimateAnomaly   `xml:"climate-anomaly"`
+	MisspelledClimateAnomalies   []DataParametersClimateAnomaly   `xml:"climate-anomoly"`
+	WindSpeeds                   []DataParametersSection          `xml:"wind-speed"`
+	Directions                   []DataParametersSection          `xml:"direction"`
+	CloudAmounts                 []DataParametersSection          `xml:"cloud-amount"`
 	Humidities                   []DataParametersSection          `xml:"humidity"`
 	Weathers                     DataParametersWeather            `xml:"weather"`
 	ConditionsIcon               DataParametersConditionsIcon     `xml:"conditions-icon"`
 	Hazards                      DataParametersHazards            `xml:"hazards"`
+	WordedForecast               DataParametersWordedForecast     `xml:"wordedForecast"`
 	WaterState                   DataParametersWaterState         `xml:"water-state"`
 }
 
 func GetParametersSection(dps []DataParametersSection, name string) (string, string, []float64, error) {
+	timeLayout, units, strs, err := GetParametersSectionStrings(dps, name)
+
+	if err != nil {
+		return timeLayout, units, []float64{}, err
+	}
+
+	vals := make([]float64, 0, len(strs))
+
+	for _, v := range strs {
+		i, err := strconv.ParseFloat(v, 64)
+
+		if err != nil {
+			vals = append(vals, math.NaN())
+		} else {
+			vals = append(vals, i)
+		}
+	}
+
+	return timeLayout, units, vals, nil
+}
+
+func GetParametersSectionStrings(dps []DataParametersSection, name string) (string, string, []string, error) {
 	timeLayout := "unknown"
 	units := "unknown"
-	vals := make([]float64, 0, 64)
+	vals := make([]string, 0, 64)
 
 	for _, t := range dps {
 		if t.Type == name {
 			timeLayout = t.TimeLayout
 			units = t.Units
-
-			for _, v := range t.Values {
-				i, err := strconv.ParseFloat(v, 64)
-
-				if err != nil {
-					vals = append(vals, math.NaN())
-				} else {
-					vals = append(vals, i)
-				}
-			}
+			vals = append(vals, t.Values...)
 		}
 	}
 
 	if timeLayout == "unknown" {
-		return timeLayout, units, []float64{}, errors.New(fmt.Sprintf("Could not find section %s", name))
+		return timeLayout, units, []string{}, errors.New(fmt.Sprintf("Could not find section %s", name))
 	}
 
 	return timeLayout, units, vals, nil
@@ -367,6 +647,18 @@ func (dp DataParameters) HourlyTemperatures() (string, string, []float64, error)
 	return GetParametersSection(dp.Temperatures, "hourly")
 }
 
+func (dp DataParameters) DailyMaximumTemperatures() (string, string, []float64, error) {
+	return GetParametersSection(dp.Temperatures, "maximum")
+}
+
+func (dp DataParameters) DailyMinimumTemperatures() (string, string, []float64, error) {
+	return GetParametersSection(dp.Temperatures, "minimum")
+}
+
+func (dp DataParameters) TwelveHourPoPs() (string, string, []float64, error) {
+	return GetParametersSection(dp.ProbabilitiesOfPrecipitation, "12 hour")
+}
+
 func (dp DataParameters) HourlyDewPoints() (string, string, []float64, error) {
 	return GetParametersSection(dp.Temperatures, "dew point")
 }
@@ -391,9 +683,25 @@ func (dp DataParameters) HourlySnowAmounts() (string, string, []float64, error)
 	return GetParametersSection(dp.Precipitations, "snow")
 }
 
+func (dp DataParameters) HourlyRelativeHumidities() (string, string, []float64, error) {
+	return GetParametersSection(dp.Humidities, "relative")
+}
+
+func (dp DataParameters) MinimumRelativeHumidities() (string, string, []float64, error) {
+	return GetParametersSection(dp.Humidities, "minimum relative")
+}
+
+func (dp DataParameters) MaximumRelativeHumidities() (string, string, []float64, error) {
+	return GetParametersSection(dp.Humidities, "maximum relative")
+}
+
+func (dp DataParameters) HourlyWindGusts() (string, string, []float64, error) {
+	return GetParametersSection(dp.WindSpeeds, "gust")
+}
+
 type DataParametersSection struct {
-	Type       string   `xml:"type,attr"`
-	Units      string   `xml:"units,attr"`
+	Type       string   `xml:"type,attr,omitempty"`
+	Units      string   `xml:"units,attr,omitempty"`
 	TimeLayout string   `xml:"time-layout,attr"`
 	Name       string   `xml:"name"`
 	Values     []string `xml:"value"`
@@ -405,9 +713,9 @@ type DataParametersConvectiveHazard struct {
 }
 
 type DataParametersClimateAnomaly struct {
-	Weekly   DataParametersSection `xml:"weekly"`
-	Monthly  DataParametersSection `xml:"monthly"`
-	Seasonal DataParametersSection `xml:"seasonal"`
+	Weekly   []DataParametersSection `xml:"weekly"`
+	Monthly  []DataParametersSection `xml:"monthly"`
+	Seasonal []DataParametersSection `xml:"seasonal"`
 }
 
 type DataParametersWeather struct {
@@ -417,36 +725,59 @@ type DataParametersWeather struct {
 }
 
 type DataParametersWeatherConditions struct {
-	Value DataParametersWeatherConditionsValue `xml:"value"`
+	WeatherSummary string                                 `xml:"weather-summary,attr,omitempty"`
+	Values         []DataParametersWeatherConditionsValue `xml:"value"`
 }
 
 type DataParametersWeatherConditionsValue struct {
 	Coverage    string                                         `xml:"coverage,attr"`
 	Intensity   string                                         `xml:"intensity,attr"`
+	Additive    string                                         `xml:"additive,attr,omitempty"`
 	WeatherType string                                         `xml:"weather-type,attr"`
-	Qualifier   string                                         `xml:qualifier,attr"`
+	Qualifier   string                                         `xml:"qualifier,attr"`
 	Visibility  DataParametersWeatherConditionsValueVisibility `xml:"visibility"`
 }
 
 type DataParametersWeatherConditionsValueVisibility struct {
-	Units string `xml:"units,attr"`
+	Units string `xml:"units,attr,omitempty"`
 	Value string `xml:",chardata"`
 }
 
 type DataParametersConditionsIcon struct {
-	Type       string   `xml:"type,attr"`
+	Type       string   `xml:"type,attr,omitempty"`
 	TimeLayout string   `xml:"time-layout,attr"`
 	Name       string   `xml:"name"`
-	IconLink   []string `xml"icon-link"`
+	IconLink   []string `xml:"icon-link"`
+}
+
+type DataParametersWordedForecast struct {
+	TimeLayout    string   `xml:"time-layout,attr"`
+	DataSource    string   `xml:"dataSource,attr,omitempty"`
+	WordGenerator string   `xml:"wordGenerator,attr,omitempty"`
+	Name          string   `xml:"name"`
+	Texts         []string `xml:"text"`
 }
 
 type DataParametersHazards struct {
-	TimeLayout       string `xml:"time-layout,attr"`
-	Name             string `xml:"name"`
-	HazardConditions []interface{}
+	TimeLayout       string                           `xml:"time-layout,attr"`
+	Name             string                           `xml:"name"`
+	HazardConditions []DataParametersHazardConditions `xml:"hazard-conditions"`
+}
+
+type DataParametersHazardConditions struct {
+	Hazards []DataParametersHazard `xml:"hazard"`
+}
+
+type DataParametersHazard struct {
+	HazardCode    string `xml:"hazardCode,attr"`
+	Phenomena     string `xml:"phenomena,attr"`
+	Significance  string `xml:"significance,attr"`
+	HazardType    string `xml:"hazardType,attr,omitempty"`
+	HazardTextURL string `xml:"hazardTextURL,omitempty"`
+	HazardIcon    string `xml:"hazardIcon,omitempty"`
 }
 
 type DataParametersWaterState struct {
-	TimeLayout string `xml:"time-layout,attr"`
-	Waves      DataParametersSection
+	TimeLayout string                `xml:"time-layout,attr"`
+	Waves      DataParametersSection `xml:"waves"`
 }
@@ -1,29 +1,90 @@
 package ndfd
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 var ndfdGlobal NDFD
 
+func loadDWML(t *testing.T, name string) *DWML {
+	f, err := os.Open(filepath.Join("testdata", name))
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	defer f.Close()
+	dwml, err := ParseDWML(f)
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	return dwml
+}
+
 func TestFetchAndDecode(t *testing.T) {
+	if os.Getenv("NDFD_LIVE") == "" {
+		t.Skip("set NDFD_LIVE to fetch from the NWS server")
+	}
+
 	n, err := FetchNDFD(39.640102, -106.374332)
 
 	if err != nil {
 		t.Errorf("%s", err)
 	}
 
+	fmt.Printf("%+v\n", n.Dwml.Data.Parameters)
+}
+
+func TestParseAndDecode(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "time-series.xml"))
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	defer f.Close()
+	n, err := ParseNDFD(f)
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
 	ndfdGlobal = n
 	fmt.Printf("%+v\n", n.Dwml.Data.Parameters)
+
+	if n.Dwml.Head.Product.CreationDate.Value != "2016-03-01T23:48:26Z" {
+		t.Errorf("Creation date decoded as %s", n.Dwml.Head.Product.CreationDate.Value)
+	}
+
+	if !n.CreationDate.Equal(time.Date(2016, 3, 1, 23, 48, 26, 0, time.UTC)) {
+		t.Errorf("NDFD creation date set to %s", n.CreationDate)
+	}
+
+	numConditions := 0
+
+	for range n.Conditions.All() {
+		numConditions++
+	}
+
+	if numConditions == 0 {
+		t.Errorf("No conditions decoded")
+	}
 }
 
 func TestDataLocation(t *testing.T) {
-	fmt.Printf("%+v\n", ndfdGlobal.Dwml.Data.Location)
+	fmt.Printf("%+v\n", ndfdGlobal.Dwml.Data.Locations)
 }
 
 func TestHourlyVals(t *testing.T) {
-	timeLayout, units, vals, err := ndfdGlobal.Dwml.Data.Parameters.HourlyTemperatures()
+	timeLayout, units, vals, err := ndfdGlobal.Dwml.Data.Parameters[0].HourlyTemperatures()
 
 	if err != nil {
 		t.Errorf("%s", err)
@@ -33,7 +94,7 @@ func TestHourlyVals(t *testing.T) {
 	fmt.Println(units)
 	fmt.Println(vals)
 
-	timeLayout, units, vals, err = ndfdGlobal.Dwml.Data.Parameters.HourlyDewPoints()
+	timeLayout, units, vals, err = ndfdGlobal.Dwml.Data.Parameters[0].HourlyDewPoints()
 
 	if err != nil {
 		t.Errorf("%s", err)
@@ -43,7 +104,7 @@ func TestHourlyVals(t *testing.T) {
 	fmt.Println(units)
 	fmt.Println(vals)
 
-	timeLayout, units, vals, err = ndfdGlobal.Dwml.Data.Parameters.HourlyCloudAmounts()
+	timeLayout, units, vals, err = ndfdGlobal.Dwml.Data.Parameters[0].HourlyCloudAmounts()
 
 	if err != nil {
 		t.Errorf("%s", err)
@@ -53,7 +114,7 @@ func TestHourlyVals(t *testing.T) {
 	fmt.Println(units)
 	fmt.Println(vals)
 
-	timeLayout, units, vals, err = ndfdGlobal.Dwml.Data.Parameters.HourlyLiquidPrecip()
+	timeLayout, units, vals, err = ndfdGlobal.Dwml.Data.Parameters[0].HourlyLiquidPrecip()
 
 	if err != nil {
 		t.Errorf("%s", err)
@@ -63,7 +124,7 @@ func TestHourlyVals(t *testing.T) {
 	fmt.Println(units)
 	fmt.Println(vals)
 
-	timeLayout, units, vals, err = ndfdGlobal.Dwml.Data.Parameters.HourlyWindSpeeds()
+	timeLayout, units, vals, err = ndfdGlobal.Dwml.Data.Parameters[0].HourlyWindSpeeds()
 
 	if err != nil {
 		t.Errorf("%s", err)
@@ -73,7 +134,7 @@ func TestHourlyVals(t *testing.T) {
 	fmt.Println(units)
 	fmt.Println(vals)
 
-	timeLayout, units, vals, err = ndfdGlobal.Dwml.Data.Parameters.HourlyWindDirections()
+	timeLayout, units, vals, err = ndfdGlobal.Dwml.Data.Parameters[0].HourlyWindDirections()
 
 	if err != nil {
 		t.Errorf("%s", err)
@@ -83,7 +144,7 @@ func TestHourlyVals(t *testing.T) {
 	fmt.Println(units)
 	fmt.Println(vals)
 
-	timeLayout, units, vals, err = ndfdGlobal.Dwml.Data.Parameters.HourlySnowAmounts()
+	timeLayout, units, vals, err = ndfdGlobal.Dwml.Data.Parameters[0].HourlySnowAmounts()
 
 	if err != nil {
 		t.Errorf("%s", err)
@@ -95,13 +156,121 @@ func TestHourlyVals(t *testing.T) {
 }
 
 func TestTimeSpanConditions(t *testing.T) {
-	condChan, err := ndfdGlobal.Dwml.collectConditions()
+	condChan, err := ndfdGlobal.Dwml.Conditions()
 
 	if err != nil {
 		t.Errorf("%s", err)
 	}
 
+	counts := make(map[string]int)
+
 	for c := range condChan {
 		fmt.Printf("%+v\n", c)
+		counts[c.Name]++
+	}
+
+	if counts["temp"] != 16 || counts["precip"] != 48 || counts["pop12"] != 48 {
+		t.Errorf("Unexpected conditions %v", counts)
+	}
+}
+
+const partialLayouts = `<dwml version="1.0">
+  <data>
+    <location>
+      <location-key>point1</location-key>
+      <point latitude="39.64" longitude="-106.37"/>
+    </location>
+    <time-layout time-coordinate="local" summarization="none">
+      <layout-key>k-p1h-n2-1</layout-key>
+      <start-valid-time>2016-03-01T17:00:00-07:00</start-valid-time>
+      <start-valid-time>2016-03-01T18:00:00-07:00</start-valid-time>
+    </time-layout>
+    <time-layout time-coordinate="local" summarization="none">
+      <layout-key>k-p1h-n2-2</layout-key>
+      <start-valid-time>2016-03-01T17:00:00</start-valid-time>
+      <start-valid-time>2016-03-01T18:00:00</start-valid-time>
+    </time-layout>
+    <time-layout time-coordinate="UTC" summarization="none">
+      <layout-key>k-p1h-n1-3</layout-key>
+      <start-valid-time>2016-03-02T00:00:00</start-valid-time>
+    </time-layout>
+    <parameters applicable-location="point1">
+      <temperature type="hourly" units="Celsius" time-layout="k-p1h-n2-1">
+        <name>Temperature</name>
+        <value>1</value>
+        <value>2</value>
+      </temperature>
+      <temperature type="dew point" units="Celsius" time-layout="k-p1h-n2-2">
+        <name>Dew Point Temperature</name>
+        <value>-5</value>
+        <value>-6</value>
+      </temperature>
+      <cloud-amount type="total" units="percent" time-layout="k-p1h-n1-3">
+        <name>Cloud Cover Amount</name>
+        <value>40</value>
+      </cloud-amount>
+    </parameters>
+  </data>
+</dwml>`
+
+func TestLayoutErrors(t *testing.T) {
+	n, err := ParseNDFD(strings.NewReader(partialLayouts))
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if len(n.LayoutErrors) != 1 || n.LayoutErrors["k-p1h-n2-2"] == nil {
+		t.Errorf("Unexpected layout errors %v", n.LayoutErrors)
+	}
+
+	counts := make(map[string]int)
+
+	for c := range n.Conditions.All() {
+		counts[c.Name]++
+
+		if c.Name == "temp" && c.Hour.Hour() != 0 && c.Hour.Hour() != 1 {
+			t.Errorf("Local time was not converted to UTC: %+v", c)
+		}
+	}
+
+	if counts["temp"] != 2 || counts["clouds"] != 1 || counts["dewpoint"] != 0 {
+		t.Errorf("Unexpected conditions %v", counts)
+	}
+
+	spans, err := n.Dwml.layoutTimeSpans("k-p1h-n2-1")
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if _, offset := spans[0].Begin.Zone(); offset != -7*3600 {
+		t.Errorf("Offset of %s was not kept", spans[0].Begin)
+	}
+
+	if _, err := n.Dwml.sectionPeriods(DataParameters.HourlyDewPoints); err == nil {
+		t.Errorf("Dew points should report the layout error")
+	}
+}
+
+func TestConditionsContext(t *testing.T) {
+	dwml := loadDWML(t, "time-series.xml")
+	ctx, cancel := context.WithCancel(context.Background())
+	condChan, err := dwml.ConditionsContext(ctx)
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	<-condChan
+	cancel()
+	n := 0
+
+	for range condChan {
+		n++
+	}
+
+	if total := len(collectAll(t, dwml)); n >= total-1 {
+		t.Errorf("Received %d of %d conditions after cancelling", n, total)
 	}
 }
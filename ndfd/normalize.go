@@ -0,0 +1,69 @@
+package ndfd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gershwinlabs/noaa/units"
+)
+
+// Unit parses the free text units DWML reported for the condition,
+// returning units.Unknown when they are not recognized.
+func (c Condition) Unit() units.Unit {
+	u, _ := units.Parse(c.Units)
+	return u
+}
+
+// ConvertTo converts the condition to u, returning an error when its
+// units are not recognized.
+func (c Condition) ConvertTo(u units.Unit) (Condition, error) {
+	from := c.Unit()
+
+	if from == units.Unknown {
+		return c, errors.New(fmt.Sprintf("Unknown units %q for %s", c.Units, c.Name))
+	}
+
+	v, err := units.Convert(c.Value, from, u)
+
+	if err != nil {
+		return c, err
+	}
+
+	c.Value = v
+	c.Units = u.String()
+	return c, nil
+}
+
+// Normalize converts the condition to the canonical unit of the system
+// for the quantity it measures.
+func (c Condition) Normalize(system units.System) (Condition, error) {
+	return c.ConvertTo(system.Canonical(c.Unit().Kind()))
+}
+
+// NormalizeConditions normalizes every condition read from in.  Conditions
+// whose units cannot be normalized are dropped.  The returned channel is
+// closed once in is closed or ctx is cancelled; pass the same ctx to
+// ConditionsContext so that neither side is left blocked.
+func NormalizeConditions(ctx context.Context, in chan Condition, system units.System) chan Condition {
+	out := make(chan Condition, 10)
+
+	go func() {
+		defer close(out)
+
+		for c := range in {
+			n, err := c.Normalize(system)
+
+			if err != nil {
+				continue
+			}
+
+			select {
+			case out <- n:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,85 @@
+package ndfd
+
+import (
+	"context"
+	"github.com/gershwinlabs/noaa/units"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestNormalizeConditions(t *testing.T) {
+	hour := time.Date(2016, 3, 1, 12, 0, 0, 0, time.UTC)
+	in := make(chan Condition, 5)
+	in <- Condition{"temp", 50, "Fahrenheit", hour, 39.64, -106.37, "point1"}
+	in <- Condition{"windspeed", 10, "knots", hour, 39.64, -106.37, "point1"}
+	in <- Condition{"precip", 0.5, "inches", hour, 39.64, -106.37, "point1"}
+	in <- Condition{"conhazo", 2, "category", hour, 39.64, -106.37, "point1"}
+	in <- Condition{"wx", 1, "", hour, 39.64, -106.37, "point1"}
+	close(in)
+
+	expected := map[string]struct {
+		v     float64
+		units string
+	}{
+		"temp":      {10, "Celsius"},
+		"windspeed": {5.144444, "meters/second"},
+		"precip":    {12.7, "millimeters"},
+		"conhazo":   {2, "category"},
+	}
+
+	n := 0
+
+	for c := range NormalizeConditions(context.Background(), in, units.Metric) {
+		e, ok := expected[c.Name]
+		n++
+
+		if !ok {
+			t.Errorf("Condition with unknown units was not dropped: %+v", c)
+			continue
+		}
+
+		if math.Abs(c.Value-e.v) > 1e-4 || c.Units != e.units {
+			t.Errorf("%s normalized to %v %s", c.Name, c.Value, c.Units)
+		}
+	}
+
+	if n != len(expected) {
+		t.Errorf("%d conditions normalized, expected %d", n, len(expected))
+	}
+}
+
+func TestNormalizeConditionsCancel(t *testing.T) {
+	dwml := loadDWML(t, "time-series.xml")
+	ctx, cancel := context.WithCancel(context.Background())
+	in, err := dwml.ConditionsContext(ctx)
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	out := NormalizeConditions(ctx, in, units.Metric)
+	<-out
+	cancel()
+
+	// both channels close instead of leaving their goroutines blocked
+	for range out {
+	}
+
+	for range in {
+	}
+}
+
+func TestNormalizeUnknownUnits(t *testing.T) {
+	for _, u := range []string{"", "furlongs", "m"} {
+		c := Condition{Name: "temp", Value: 50, Units: u}
+
+		if _, err := c.Normalize(units.Metric); err == nil {
+			t.Errorf("Normalized condition with units %q", u)
+		}
+
+		if _, err := c.ConvertTo(units.Unknown); err == nil {
+			t.Errorf("Converted condition with units %q", u)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package ndfd
+
+import (
+	"fmt"
+	"github.com/gershwinlabs/noaa"
+	"github.com/gershwinlabs/noaa/units"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Selection describes the points an NDFD request is made for.  Each
+// kind of selection maps to its own NDFDgen client.
+type Selection interface {
+	clientSuffix() string
+	query() url.Values
+}
+
+type Point struct {
+	Lat float64
+	Lon float64
+}
+
+type PointList []Point
+
+type ZipCodeList []string
+
+type Line struct {
+	From Point
+	To   Point
+}
+
+// Subgrid selects the grid points within the rectangle between the
+// LowerLeft and UpperRight corners, every Resolution kilometers.
+type Subgrid struct {
+	LowerLeft  Point
+	UpperRight Point
+	Resolution float64
+}
+
+type CityList int
+
+const (
+	CitiesLevel1 CityList = 1
+	CitiesLevel2 CityList = 2
+	CitiesLevel3 CityList = 3
+	CitiesLevel4 CityList = 4
+	AllCities    CityList = 1234
+)
+
+func (p Point) clientSuffix() string {
+	return ""
+}
+
+func (p Point) query() url.Values {
+	q := url.Values{}
+	q.Set("lat", fmt.Sprintf("%f", p.Lat))
+	q.Set("lon", fmt.Sprintf("%f", p.Lon))
+	return q
+}
+
+func (pl PointList) clientSuffix() string {
+	return "LatLonList"
+}
+
+func (pl PointList) query() url.Values {
+	pairs := make([]string, len(pl))
+
+	for i, p := range pl {
+		pairs[i] = fmt.Sprintf("%f,%f", p.Lat, p.Lon)
+	}
+
+	q := url.Values{}
+	q.Set("listLatLon", strings.Join(pairs, " "))
+	return q
+}
+
+func (zl ZipCodeList) clientSuffix() string {
+	return "MultiZipCode"
+}
+
+func (zl ZipCodeList) query() url.Values {
+	q := url.Values{}
+	q.Set("zipCodeList", strings.Join(zl, " "))
+	return q
+}
+
+func (l Line) clientSuffix() string {
+	return "Line"
+}
+
+func (l Line) query() url.Values {
+	q := url.Values{}
+	q.Set("endPoint1Lat", fmt.Sprintf("%f", l.From.Lat))
+	q.Set("endPoint1Lon", fmt.Sprintf("%f", l.From.Lon))
+	q.Set("endPoint2Lat", fmt.Sprintf("%f", l.To.Lat))
+	q.Set("endPoint2Lon", fmt.Sprintf("%f", l.To.Lon))
+	return q
+}
+
+func (sg Subgrid) clientSuffix() string {
+	return "Subgrid"
+}
+
+func (sg Subgrid) query() url.Values {
+	q := url.Values{}
+	q.Set("lat1", fmt.Sprintf("%f", sg.LowerLeft.Lat))
+	q.Set("lon1", fmt.Sprintf("%f", sg.LowerLeft.Lon))
+	q.Set("lat2", fmt.Sprintf("%f", sg.UpperRight.Lat))
+	q.Set("lon2", fmt.Sprintf("%f", sg.UpperRight.Lon))
+
+	if sg.Resolution > 0 {
+		q.Set("resolutionSub", fmt.Sprintf("%f", sg.Resolution))
+	}
+
+	return q
+}
+
+func (cl CityList) clientSuffix() string {
+	return "MultiCities"
+}
+
+func (cl CityList) query() url.Values {
+	q := url.Values{}
+	q.Set("citiesLevel", fmt.Sprintf("%d", cl))
+	return q
+}
+
+// Request is a time-series request for every element of the NDFD.
+type Request struct {
+	Selection Selection
+	TimeSpan  noaa.TimeSpan
+	Units     units.System
+}
+
+func (r Request) URL() string {
+	q := r.Selection.query()
+	q.Set("whichClient", "NDFDgen"+r.Selection.clientSuffix())
+	q.Set("product", "time-series")
+	q.Set("begin", r.TimeSpan.Begin.UTC().Format(time.RFC3339))
+	q.Set("end", r.TimeSpan.End.UTC().Format(time.RFC3339))
+	q.Set("Unit", r.Units.Param())
+	return sourceURLBase + "?" + q.Encode() + "&" + elementQuery
+}
@@ -0,0 +1,127 @@
+package ndfd
+
+import (
+	"github.com/gershwinlabs/noaa"
+	"github.com/gershwinlabs/noaa/units"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRequestURL(t *testing.T) {
+	begin := time.Date(2016, 3, 1, 0, 0, 0, 0, time.UTC)
+	ts := noaa.TimeSpan{Begin: begin, End: begin.Add(24 * time.Hour)}
+	tests := []struct {
+		sel    Selection
+		client string
+		param  string
+		value  string
+	}{
+		{Point{38.99, -77.02}, "NDFDgen", "lat", "38.990000"},
+		{PointList{{38.99, -77.02}, {39.70, -104.80}}, "NDFDgenLatLonList", "listLatLon", "38.990000,-77.020000 39.700000,-104.800000"},
+		{ZipCodeList{"20910", "25414"}, "NDFDgenMultiZipCode", "zipCodeList", "20910 25414"},
+		{Line{Point{39, -77}, Point{39, -104}}, "NDFDgenLine", "endPoint2Lon", "-104.000000"},
+		{Subgrid{Point{35, -80}, Point{36, -79}, 20}, "NDFDgenSubgrid", "resolutionSub", "20.000000"},
+		{AllCities, "NDFDgenMultiCities", "citiesLevel", "1234"},
+	}
+
+	for _, test := range tests {
+		u, err := url.Parse(Request{test.sel, ts, units.Metric}.URL())
+
+		if err != nil {
+			t.Errorf("%s", err)
+			continue
+		}
+
+		q := u.Query()
+
+		if q.Get("whichClient") != test.client || q.Get(test.param) != test.value {
+			t.Errorf("Unexpected query for %T: %s", test.sel, u.RawQuery)
+		}
+
+		if q.Get("begin") != "2016-03-01T00:00:00Z" || q.Get("end") != "2016-03-02T00:00:00Z" {
+			t.Errorf("Time span not sent in UTC: %s", u.RawQuery)
+		}
+
+		if q.Get("temp") != "temp" || !strings.HasSuffix(u.RawQuery, "Submit=Submit") {
+			t.Errorf("Elements missing from query for %T: %s", test.sel, u.RawQuery)
+		}
+	}
+}
+
+func TestMultiPointConditions(t *testing.T) {
+	dwml := loadDWML(t, "multi-point.xml")
+
+	if len(dwml.Data.Locations) != 2 || len(dwml.Data.Parameters) != 2 {
+		t.Fatalf("Decoded %d locations and %d parameters", len(dwml.Data.Locations), len(dwml.Data.Parameters))
+	}
+
+	condChan, err := dwml.Conditions()
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	for c := range condChan {
+		switch c.Location {
+		case "point1":
+			if c.Value < 0 || c.Lat != 38.99 {
+				t.Errorf("Condition attributed to the wrong point: %+v", c)
+			}
+		case "point2":
+			if c.Value > 0 || c.Lon != -104.80 {
+				t.Errorf("Condition attributed to the wrong point: %+v", c)
+			}
+		default:
+			t.Errorf("Condition without a location: %+v", c)
+		}
+	}
+
+	if _, err := dwml.sectionPeriods(DataParameters.HourlyTemperatures); err == nil {
+		t.Errorf("Single point accessor should fail on multiple points")
+	}
+
+	point2, err := dwml.ForLocation("point2")
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	periods, err := point2.sectionPeriods(DataParameters.HourlyTemperatures)
+
+	if err != nil || len(periods) != 2 || periods[1].Value != -1 {
+		t.Errorf("Point 2 temperatures decoded as %+v %v", periods, err)
+	}
+
+	if len(point2.Data.MoreWeatherInformation) != 1 {
+		t.Errorf("Point 2 has %d more weather information links", len(point2.Data.MoreWeatherInformation))
+	}
+}
+
+func TestMissingLocationConditions(t *testing.T) {
+	dwml := loadDWML(t, "multi-point.xml")
+	dwml.Data.Parameters[1].ApplicableLocation = "point3"
+
+	condChan, err := dwml.Conditions()
+
+	if err == nil {
+		t.Errorf("Conditions collected for a missing location")
+	}
+
+	for c := range condChan {
+		t.Errorf("Condition sent for a missing location: %+v", c)
+	}
+
+	table := dwml.Table()
+
+	if table.Len() == 0 {
+		t.Errorf("Table dropped the conditions for point1")
+	}
+
+	for c := range table.All() {
+		if c.Location != "point1" {
+			t.Errorf("Table has a condition for a missing location: %+v", c)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package ndfd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gershwinlabs/noaa"
+	"github.com/gershwinlabs/noaa/units"
+	"time"
+)
+
+var conditionVariables = map[string]noaa.Variable{
+	"temp":      noaa.Temperature,
+	"dewpoint":  noaa.DewPoint,
+	"maxt":      noaa.MaxTemperature,
+	"mint":      noaa.MinTemperature,
+	"pop12":     noaa.PrecipitationProbability,
+	"clouds":    noaa.CloudCover,
+	"precip":    noaa.Precipitation,
+	"windspeed": noaa.WindSpeed,
+	"winddir":   noaa.WindDirection,
+	"snow":      noaa.Snowfall,
+	"rh":        noaa.RelativeHumidity,
+	"wgust":     noaa.WindGust,
+}
+
+// ConditionVariable returns the variable measured by the Conditions
+// with the given name.  Categorical elements have no variable.
+func ConditionVariable(name string) (noaa.Variable, bool) {
+	v, ok := conditionVariables[name]
+	return v, ok
+}
+
+// ConditionPeriod returns the length of the forecast periods of the
+// Conditions with the given name, or zero for instantaneous elements.
+func ConditionPeriod(name string) time.Duration {
+	return time.Duration(encodedElements[name].period) * time.Hour
+}
+
+// ConditionName returns the name of the Conditions that forecast v.
+func ConditionName(v noaa.Variable) (string, bool) {
+	for name, variable := range conditionVariables {
+		if variable == v {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+// Datum converts the condition to a noaa.Datum for a forecast issued at
+// issued.  Conditions for period elements are valid for their hour,
+// though accumulations such as precip are the total for the whole
+// forecast period; use DWML.Series to keep the periods.
+func (c Condition) Datum(issued time.Time) (noaa.Datum, error) {
+	v, ok := conditionVariables[c.Name]
+
+	if !ok {
+		return noaa.Datum{}, errors.New(fmt.Sprintf("No variable for condition %s", c.Name))
+	}
+
+	valid := noaa.TimeSpan{Begin: c.Hour, End: c.Hour}
+
+	if encodedElements[c.Name].period > 0 {
+		valid.End = c.Hour.Add(time.Hour)
+	}
+
+	return noaa.Datum{
+		Variable:   v,
+		Location:   noaa.Location{ID: c.Location, Lat: c.Lat, Lon: c.Lon},
+		Valid:      valid,
+		Value:      c.Value,
+		Unit:       c.Unit(),
+		Provenance: noaa.Provenance{Kind: noaa.Forecast, Source: "NDFD", Issued: issued},
+	}, nil
+}
+
+// Series returns a noaa.Series for each element with a variable at each
+// location, with each value valid for its forecast period.
+func (dwml *DWML) Series() ([]noaa.Series, error) {
+	issued, _ := dwml.CreationDate()
+	provenance := noaa.Provenance{Kind: noaa.Forecast, Source: "NDFD", Issued: issued}
+	data := make([]noaa.Datum, 0)
+
+	for _, dp := range dwml.Data.Parameters {
+		loc, err := dwml.Data.applicableLocation(dp.ApplicableLocation)
+
+		if err != nil {
+			return []noaa.Series{}, err
+		}
+
+		location := noaa.Location{ID: loc.LocationKey, Lat: loc.Point.Latitude, Lon: loc.Point.Longitude}
+
+		for _, section := range conditionSections {
+			v, ok := conditionVariables[section.name]
+
+			if !ok {
+				continue
+			}
+
+			periods, err := dwml.parameterPeriods(dp, section.accessor)
+
+			if err != nil {
+				continue
+			}
+
+			for _, p := range periods {
+				u, _ := units.Parse(p.Units)
+				data = append(data, noaa.Datum{Variable: v, Location: location, Valid: p.TimeSpan, Value: p.Value, Unit: u, Provenance: provenance})
+			}
+		}
+	}
+
+	return noaa.NewSeries(data), nil
+}
@@ -0,0 +1,60 @@
+package ndfd
+
+import (
+	"github.com/gershwinlabs/noaa"
+	"github.com/gershwinlabs/noaa/units"
+	"testing"
+	"time"
+)
+
+func TestDWMLSeries(t *testing.T) {
+	dwml := loadDWML(t, "time-series.xml")
+	series, err := dwml.Series()
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	byVariable := make(map[noaa.Variable]noaa.Series)
+
+	for _, s := range series {
+		byVariable[s.Variable] = s
+	}
+
+	precip := byVariable[noaa.Precipitation]
+
+	if len(precip.Samples) != 8 || precip.Samples[0].Valid.Duration() != 6*time.Hour || precip.Unit != units.Millimeters {
+		t.Errorf("Precipitation series %+v", precip)
+	}
+
+	temp := byVariable[noaa.Temperature]
+
+	if len(temp.Samples) != 16 || temp.Samples[0].Valid.Duration() != 0 || temp.Location.ID != "point1" || temp.Provenance.Kind != noaa.Forecast || temp.Provenance.Issued.IsZero() {
+		t.Errorf("Temperature series %+v", temp)
+	}
+
+	if _, ok := byVariable[""]; ok {
+		t.Errorf("Categorical elements should not have series")
+	}
+}
+
+func TestConditionDatum(t *testing.T) {
+	hour := time.Date(2016, 3, 2, 0, 0, 0, 0, time.UTC)
+	d, err := Condition{"precip", 1.5, "millimeters", hour, 39.64, -106.37, "point1"}.Datum(hour.Add(-time.Hour))
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if d.Variable != noaa.Precipitation || d.Valid.Duration() != time.Hour || d.Unit != units.Millimeters || d.Provenance.Source != "NDFD" {
+		t.Errorf("Condition converted to %+v", d)
+	}
+
+	if _, err := (Condition{Name: "conhazo"}).Datum(hour); err == nil {
+		t.Errorf("Categorical condition should not convert")
+	}
+
+	if name, ok := ConditionName(noaa.WindGust); !ok || name != "wgust" {
+		t.Errorf("Wind gust condition named %s", name)
+	}
+}
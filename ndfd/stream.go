@@ -0,0 +1,165 @@
+package ndfd
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"github.com/gershwinlabs/noaa"
+	"io"
+	"net/http"
+)
+
+// ConditionStream delivers the Conditions of a DWML document while it
+// is still being read.  Conditions is closed when the document has been
+// read, decoding fails or the context is cancelled; Err reports why.
+type ConditionStream struct {
+	Conditions chan Condition
+	err        error
+}
+
+// Err returns the error that ended the stream, or nil if the whole
+// document was read.  It is only valid once Conditions has been closed.
+func (s *ConditionStream) Err() error {
+	return s.err
+}
+
+// StreamConditions decodes the DWML document in r one element at a
+// time, sending the Conditions for each parameters element as soon as it
+// has been read.  Only one parameters element is held in memory at a
+// time, so large subgrid and list responses can be processed.  Sends
+// block until the consumer is ready; cancel ctx to abandon the stream.
+func StreamConditions(ctx context.Context, r io.Reader) *ConditionStream {
+	return startStream(ctx, r, func() {})
+}
+
+// StreamRequestWithClient makes the request and streams the Conditions
+// from the response.  The response body is closed when the stream ends.
+func StreamRequestWithClient(ctx context.Context, client *http.Client, r Request) (*ConditionStream, error) {
+	sourceURL := r.URL()
+	req, err := http.NewRequestWithContext(ctx, "GET", sourceURL, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != 200 {
+		resp.Body.Close()
+		return nil, errors.New(fmt.Sprintf("Received error %d from %s", resp.StatusCode, sourceURL))
+	}
+
+	return startStream(ctx, resp.Body, func() { resp.Body.Close() }), nil
+}
+
+func startStream(ctx context.Context, r io.Reader, done func()) *ConditionStream {
+	s := &ConditionStream{make(chan Condition), nil}
+
+	go func() {
+		s.err = streamDWML(ctx, xml.NewDecoder(r), s.Conditions)
+		done()
+		close(s.Conditions)
+	}()
+
+	return s
+}
+
+func streamDWML(ctx context.Context, decoder *xml.Decoder, condChan chan Condition) error {
+	locations := make(map[string]DataLocation)
+	tsMap := make(map[string][]noaa.TimeSpan)
+	var onlyLocation DataLocation
+	layoutErrs := make(LayoutErrors)
+
+	send := func(c Condition) bool {
+		if ctx.Err() != nil {
+			return false
+		}
+
+		select {
+		case condChan <- c:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		token, err := decoder.Token()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		start, ok := token.(xml.StartElement)
+
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "location":
+			var loc DataLocation
+
+			if err := decoder.DecodeElement(&loc, &start); err != nil {
+				return err
+			}
+
+			locations[loc.LocationKey] = loc
+			onlyLocation = loc
+		case "time-layout":
+			var tl DataTimeLayout
+
+			if err := decoder.DecodeElement(&tl, &start); err != nil {
+				return err
+			}
+
+			spans, err := tl.timeSpans()
+
+			if err != nil {
+				layoutErrs[tl.LayoutKey] = err
+				continue
+			}
+
+			tsMap[tl.LayoutKey] = spans
+		case "parameters":
+			var dp DataParameters
+
+			if err := decoder.DecodeElement(&dp, &start); err != nil {
+				return err
+			}
+
+			loc, ok := locations[dp.ApplicableLocation]
+
+			if !ok {
+				if dp.ApplicableLocation != "" || len(locations) != 1 {
+					return errors.New(fmt.Sprintf("Could not find location %s", dp.ApplicableLocation))
+				}
+
+				loc = onlyLocation
+			}
+
+			if !parameterConditions(dp, loc, tsMap, send) {
+				return ctx.Err()
+			}
+		}
+	}
+
+	if len(layoutErrs) > 0 {
+		return layoutErrs
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package ndfd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStreamConditions(t *testing.T) {
+	for _, fixture := range []string{"time-series.xml", "multi-point.xml"} {
+		f, err := os.Open(filepath.Join("testdata", fixture))
+
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+
+		streamed := make(map[Condition]int)
+		s := StreamConditions(context.Background(), f)
+
+		for c := range s.Conditions {
+			streamed[c]++
+		}
+
+		f.Close()
+
+		if s.Err() != nil {
+			t.Errorf("%s: %s", fixture, s.Err())
+		}
+
+		collected := collectAll(t, loadDWML(t, fixture))
+
+		for _, c := range collected {
+			streamed[c]--
+		}
+
+		for c, n := range streamed {
+			if n != 0 {
+				t.Errorf("%s: streamed %+v %d more times than collected", fixture, c, n)
+			}
+		}
+	}
+}
+
+func TestStreamCancel(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "time-series.xml"))
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	defer f.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	s := StreamConditions(ctx, f)
+	<-s.Conditions
+	cancel()
+
+	select {
+	case <-drain(s.Conditions):
+	case <-time.After(time.Second):
+		t.Fatalf("Stream was not closed after cancelling")
+	}
+
+	if s.Err() != context.Canceled {
+		t.Errorf("Stream ended with %v", s.Err())
+	}
+}
+
+func drain(condChan chan Condition) chan bool {
+	done := make(chan bool)
+
+	go func() {
+		for range condChan {
+		}
+
+		close(done)
+	}()
+
+	return done
+}
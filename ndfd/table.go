@@ -0,0 +1,150 @@
+package ndfd
+
+import (
+	"context"
+	"github.com/gershwinlabs/noaa"
+	"iter"
+	"sort"
+	"time"
+)
+
+// Table holds the hourly Conditions of a forecast by element and hour.
+// Unlike a channel it can be iterated any number of times.
+type Table struct {
+	names    []string
+	elements map[string][]Condition
+}
+
+// NewTable builds a Table from conds.  Elements are kept in the order
+// they first appear, and each element's Conditions are ordered by hour.
+func NewTable(conds []Condition) *Table {
+	t := &Table{[]string{}, make(map[string][]Condition)}
+
+	for _, c := range conds {
+		if _, ok := t.elements[c.Name]; !ok {
+			t.names = append(t.names, c.Name)
+		}
+
+		t.elements[c.Name] = append(t.elements[c.Name], c)
+	}
+
+	for _, name := range t.names {
+		el := t.elements[name]
+		sort.SliceStable(el, func(i, j int) bool { return el[i].Hour.Before(el[j].Hour) })
+	}
+
+	return t
+}
+
+// Table returns the hourly Conditions for every location in the
+// document.  Elements whose time layout could not be parsed are
+// skipped; see TimeLayoutErrors.  So are parameters for locations
+// missing from the document.
+func (dwml *DWML) Table() *Table {
+	tsMap, _ := dwml.generateTimeSpanLayoutMap()
+	conds := make([]Condition, 0)
+
+	for _, dp := range dwml.Data.Parameters {
+		loc, err := dwml.Data.applicableLocation(dp.ApplicableLocation)
+
+		if err != nil {
+			continue
+		}
+
+		parameterConditions(dp, loc, tsMap, func(c Condition) bool {
+			conds = append(conds, c)
+			return true
+		})
+	}
+
+	return NewTable(conds)
+}
+
+func (t *Table) Len() int {
+	n := 0
+
+	for _, el := range t.elements {
+		n += len(el)
+	}
+
+	return n
+}
+
+// Elements returns the names of the elements in the table.
+func (t *Table) Elements() []string {
+	names := make([]string, len(t.names))
+	copy(names, t.names)
+	return names
+}
+
+// All iterates over every Condition, element by element.
+func (t *Table) All() iter.Seq[Condition] {
+	return func(yield func(Condition) bool) {
+		for _, name := range t.names {
+			for _, c := range t.elements[name] {
+				if !yield(c) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Element iterates over the Conditions for one element in hour order.
+func (t *Table) Element(name string) iter.Seq[Condition] {
+	return func(yield func(Condition) bool) {
+		for _, c := range t.elements[name] {
+			if !yield(c) {
+				return
+			}
+		}
+	}
+}
+
+// Between iterates over the Conditions whose hour is within ts,
+// including its Begin but not its End.  Only the named elements are
+// included, or every element when no names are given.
+func (t *Table) Between(ts noaa.TimeSpan, names ...string) iter.Seq[Condition] {
+	if len(names) == 0 {
+		names = t.names
+	}
+
+	return func(yield func(Condition) bool) {
+		for _, name := range names {
+			el := t.elements[name]
+			i := sort.Search(len(el), func(i int) bool { return !el[i].Hour.Before(ts.Begin) })
+
+			for ; i < len(el) && el[i].Hour.Before(ts.End); i++ {
+				if !yield(el[i]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// At iterates over the Conditions for an element at one hour, one for
+// each location that has it.
+func (t *Table) At(name string, hour time.Time) iter.Seq[Condition] {
+	return t.Between(noaa.TimeSpan{Begin: hour, End: hour.Add(time.Nanosecond)}, name)
+}
+
+// Channel sends every Condition on a new channel, which is closed once
+// all of them are sent or ctx is cancelled.
+func (t *Table) Channel(ctx context.Context) chan Condition {
+	condChan := make(chan Condition)
+
+	go func() {
+		defer close(condChan)
+
+		for c := range t.All() {
+			select {
+			case condChan <- c:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return condChan
+}
@@ -0,0 +1,77 @@
+package ndfd
+
+import (
+	"context"
+	"github.com/gershwinlabs/noaa"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTable(t *testing.T) {
+	dwml := loadDWML(t, "time-series.xml")
+	table := dwml.Table()
+	collected := collectAll(t, dwml)
+
+	if table.Len() != len(collected) || len(slices.Collect(table.All())) != len(collected) {
+		t.Fatalf("Table holds %d conditions, expected %d", table.Len(), len(collected))
+	}
+
+	if len(slices.Collect(table.All())) != table.Len() {
+		t.Errorf("Table could not be iterated a second time")
+	}
+
+	if table.Elements()[0] != "temp" {
+		t.Errorf("Elements out of order: %v", table.Elements())
+	}
+
+	temps := slices.Collect(table.Element("temp"))
+
+	if len(temps) != 16 || !slices.IsSortedFunc(temps, func(a, b Condition) int { return a.Hour.Compare(b.Hour) }) {
+		t.Errorf("Unexpected temperatures %+v", temps)
+	}
+
+	begin := time.Date(2016, 3, 2, 0, 0, 0, 0, time.UTC)
+	span := noaa.TimeSpan{Begin: begin, End: begin.Add(6 * time.Hour)}
+	precip := slices.Collect(table.Between(span, "precip", "pop12"))
+
+	if len(precip) != 12 || precip[0].Name != "precip" || precip[11].Name != "pop12" {
+		t.Errorf("Unexpected conditions between %s and %s: %+v", span.Begin, span.End, precip)
+	}
+
+	for c := range table.Between(span) {
+		if c.Hour.Before(span.Begin) || !c.Hour.Before(span.End) {
+			t.Errorf("Condition outside of span: %+v", c)
+		}
+	}
+
+	at := slices.Collect(table.At("temp", temps[1].Hour))
+
+	if len(at) != 1 || at[0] != temps[1] {
+		t.Errorf("Temperature at %s found as %+v", temps[1].Hour, at)
+	}
+}
+
+func TestTableChannel(t *testing.T) {
+	table := loadDWML(t, "time-series.xml").Table()
+	count := 0
+
+	for range table.Channel(context.Background()) {
+		count++
+	}
+
+	if count != table.Len() {
+		t.Errorf("Channel sent %d of %d conditions", count, table.Len())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	condChan := table.Channel(ctx)
+	<-condChan
+	cancel()
+
+	select {
+	case <-drain(condChan):
+	case <-time.After(time.Second):
+		t.Errorf("Channel was not closed after cancelling")
+	}
+}
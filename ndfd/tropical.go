@@ -0,0 +1,128 @@
+package ndfd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gershwinlabs/noaa"
+	"math"
+)
+
+// WindThreshold is a tropical cyclone wind speed threshold in knots.
+type WindThreshold int
+
+const (
+	Winds34 WindThreshold = 34
+	Winds50 WindThreshold = 50
+	Winds64 WindThreshold = 64
+)
+
+// WindProbabilityPeriod is the probability of tropical cyclone winds
+// exceeding Threshold during TimeSpan.  Incremental probabilities cover
+// a single 6-hour window, while cumulative ones run from the start of
+// the forecast to the end of the window.
+type WindProbabilityPeriod struct {
+	TimeSpan   noaa.TimeSpan
+	Threshold  WindThreshold
+	Cumulative bool
+	Percent    float64
+}
+
+func (dp DataParameters) IncrementalWindProbabilities(threshold WindThreshold) (string, string, []float64, error) {
+	return GetParametersSection(dp.WindSpeeds, fmt.Sprintf("incremental%d", threshold))
+}
+
+func (dp DataParameters) CumulativeWindProbabilities(threshold WindThreshold) (string, string, []float64, error) {
+	return GetParametersSection(dp.WindSpeeds, fmt.Sprintf("cumulative%d", threshold))
+}
+
+func (dp DataParameters) Incremental34KnotWindProbabilities() (string, string, []float64, error) {
+	return dp.IncrementalWindProbabilities(Winds34)
+}
+
+func (dp DataParameters) Incremental50KnotWindProbabilities() (string, string, []float64, error) {
+	return dp.IncrementalWindProbabilities(Winds50)
+}
+
+func (dp DataParameters) Incremental64KnotWindProbabilities() (string, string, []float64, error) {
+	return dp.IncrementalWindProbabilities(Winds64)
+}
+
+func (dp DataParameters) Cumulative34KnotWindProbabilities() (string, string, []float64, error) {
+	return dp.CumulativeWindProbabilities(Winds34)
+}
+
+func (dp DataParameters) Cumulative50KnotWindProbabilities() (string, string, []float64, error) {
+	return dp.CumulativeWindProbabilities(Winds50)
+}
+
+func (dp DataParameters) Cumulative64KnotWindProbabilities() (string, string, []float64, error) {
+	return dp.CumulativeWindProbabilities(Winds64)
+}
+
+func (dwml *DWML) WindProbabilityPeriods(threshold WindThreshold, cumulative bool) ([]WindProbabilityPeriod, error) {
+	accessor := DataParameters.IncrementalWindProbabilities
+
+	if cumulative {
+		accessor = DataParameters.CumulativeWindProbabilities
+	}
+
+	dp, err := dwml.Data.singleParameters()
+
+	if err != nil {
+		return []WindProbabilityPeriod{}, err
+	}
+
+	layout, _, vals, err := accessor(dp, threshold)
+
+	if err != nil {
+		return []WindProbabilityPeriod{}, err
+	}
+
+	spans, err := dwml.layoutTimeSpans(layout)
+
+	if err != nil {
+		return []WindProbabilityPeriod{}, err
+	}
+
+	periods := make([]WindProbabilityPeriod, 0, len(vals))
+
+	for i, val := range vals {
+		if math.IsNaN(val) || i >= len(spans) {
+			continue
+		}
+
+		span := spans[i]
+
+		if cumulative {
+			span.Begin = spans[0].Begin
+		}
+
+		periods = append(periods, WindProbabilityPeriod{span, threshold, cumulative, val})
+	}
+
+	return periods, nil
+}
+
+// PeakWindProbability returns the 6-hour window with the highest
+// incremental probability of winds exceeding the threshold.
+func (dwml *DWML) PeakWindProbability(threshold WindThreshold) (WindProbabilityPeriod, error) {
+	periods, err := dwml.WindProbabilityPeriods(threshold, false)
+
+	if err != nil {
+		return WindProbabilityPeriod{}, err
+	}
+
+	if len(periods) == 0 {
+		return WindProbabilityPeriod{}, errors.New(fmt.Sprintf("No %d knot wind probabilities found", threshold))
+	}
+
+	peak := periods[0]
+
+	for _, p := range periods[1:] {
+		if p.Percent > peak.Percent {
+			peak = p
+		}
+	}
+
+	return peak, nil
+}
@@ -0,0 +1,36 @@
+package ndfd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWindProbabilities(t *testing.T) {
+	dwml := loadDWML(t, "tropical-wind.xml")
+
+	peak, err := dwml.PeakWindProbability(Winds34)
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if peak.Percent != 31 || peak.TimeSpan.Begin.Hour() != 8 || peak.TimeSpan.End.Hour() != 14 {
+		t.Errorf("Peak decoded as %+v", peak)
+	}
+
+	cumulative, err := dwml.WindProbabilityPeriods(Winds34, true)
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	last := cumulative[len(cumulative)-1]
+
+	if last.Percent != 51 || !last.Cumulative || last.TimeSpan.End.Sub(last.TimeSpan.Begin) != 18*time.Hour {
+		t.Errorf("Last cumulative period decoded as %+v", last)
+	}
+
+	if _, err := dwml.PeakWindProbability(Winds64); err == nil {
+		t.Errorf("64 knot probabilities should be missing")
+	}
+}
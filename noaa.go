@@ -9,7 +9,46 @@ type TimeSpan struct {
 	End   time.Time
 }
 
+// Rounding is how an end of a TimeSpan is aligned to the hour.
+type Rounding int
+
+const (
+	RoundNearest Rounding = iota
+	RoundFloor
+	RoundCeil
+)
+
+// HourOptions controls how HoursWith enumerates the hours of a span.  The
+// hours run from the aligned Begin up to the aligned End, which is only
+// included when InclusiveEnd is set.  AtLeastOne returns the aligned
+// Begin for spans that would otherwise have no hours.
+type HourOptions struct {
+	Begin        Rounding
+	End          Rounding
+	InclusiveEnd bool
+	AtLeastOne   bool
+}
+
+var (
+	// LegacyHours is the behavior of Hours: both ends are rounded to the
+	// nearest hour, and a span always has at least one hour.
+	LegacyHours = HourOptions{RoundNearest, RoundNearest, false, true}
+
+	// HalfOpenHours are exactly the whole hours h with Begin <= h < End.
+	HalfOpenHours = HourOptions{RoundCeil, RoundCeil, false, false}
+
+	// OverlappingHours are the hours whose hour long period overlaps the
+	// span.
+	OverlappingHours = HourOptions{RoundFloor, RoundCeil, false, true}
+)
+
+// Hours returns the UTC hours of the span using LegacyHours.  Use
+// HoursWith for exact alignment.
 func (ts TimeSpan) Hours() []time.Time {
+	return ts.HoursWith(LegacyHours)
+}
+
+func (ts TimeSpan) HoursWith(opts HourOptions) []time.Time {
 	begin := ts.Begin.UTC()
 	end := ts.End.UTC()
 
@@ -17,24 +56,177 @@ func (ts TimeSpan) Hours() []time.Time {
 		begin, end = end, begin
 	}
 
-	begin = begin.Round(time.Hour)
-	end = end.Round(time.Hour)
+	begin = alignHour(begin, opts.Begin)
+	end = alignHour(end, opts.End)
 
-	if begin == end {
-		return []time.Time{begin}
+	if opts.InclusiveEnd {
+		end = end.Add(time.Hour)
 	}
 
-	numHours := int(end.Sub(begin).Hours())
+	times := make([]time.Time, 0)
+
+	for t := begin; t.Before(end); t = t.Add(time.Hour) {
+		times = append(times, t)
+	}
 
-	if numHours == 0 {
+	if len(times) == 0 && opts.AtLeastOne {
 		return []time.Time{begin}
 	}
 
-	times := make([]time.Time, numHours)
+	return times
+}
+
+func alignHour(t time.Time, r Rounding) time.Time {
+	switch r {
+	case RoundFloor:
+		return t.Truncate(time.Hour)
+	case RoundCeil:
+		floor := t.Truncate(time.Hour)
+
+		if floor.Equal(t) {
+			return floor
+		}
+
+		return floor.Add(time.Hour)
+	}
+
+	return t.Round(time.Hour)
+}
+
+// Step is a resolution to step through a TimeSpan at.  Months and Days
+// are calendar steps that keep the wall clock time in the location of
+// the TimeSpan across DST changes, while Duration is elapsed time.
+type Step struct {
+	Months   int
+	Days     int
+	Duration time.Duration
+}
+
+var (
+	Minute = Step{Duration: time.Minute}
+	Hour   = Step{Duration: time.Hour}
+	Day    = Step{Days: 1}
+	Week   = Step{Days: 7}
+	Month  = Step{Months: 1}
+)
+
+func (ts TimeSpan) Duration() time.Duration {
+	return ts.End.Sub(ts.Begin)
+}
+
+// Contains reports whether t is within the span, including Begin but not
+// End.
+func (ts TimeSpan) Contains(t time.Time) bool {
+	return !t.Before(ts.Begin) && t.Before(ts.End)
+}
+
+// Covers reports whether every instant of other is within the span.
+func (ts TimeSpan) Covers(other TimeSpan) bool {
+	return !other.Begin.Before(ts.Begin) && !other.End.After(ts.End)
+}
+
+func (ts TimeSpan) Overlaps(other TimeSpan) bool {
+	return ts.Begin.Before(other.End) && other.Begin.Before(ts.End)
+}
+
+// Intersect returns the span covered by both spans, or false if they do
+// not overlap.
+func (ts TimeSpan) Intersect(other TimeSpan) (TimeSpan, bool) {
+	if !ts.Overlaps(other) {
+		return TimeSpan{}, false
+	}
+
+	return TimeSpan{Begin: latest(ts.Begin, other.Begin), End: earliest(ts.End, other.End)}, true
+}
+
+// Union returns the span covered by either span, or false if there is a
+// gap between them.
+func (ts TimeSpan) Union(other TimeSpan) (TimeSpan, bool) {
+	if ts.Begin.After(other.End) || other.Begin.After(ts.End) {
+		return TimeSpan{}, false
+	}
+
+	return TimeSpan{Begin: earliest(ts.Begin, other.Begin), End: latest(ts.End, other.End)}, true
+}
+
+// Split divides the span into consecutive spans of at most maxDuration,
+// leaving gap between the end of one and the beginning of the next.  The
+// last span ends at the end of the overall span.
+func (ts TimeSpan) Split(maxDuration, gap time.Duration) []TimeSpan {
+	timeSpans := make([]TimeSpan, 0, 1)
+
+	if maxDuration <= 0 || gap < 0 {
+		return timeSpans
+	}
+
+	for begin := ts.Begin; begin.Before(ts.End); {
+		end := earliest(begin.Add(maxDuration), ts.End)
+		timeSpans = append(timeSpans, TimeSpan{Begin: begin, End: end})
+		begin = end.Add(gap)
+	}
+
+	return timeSpans
+}
+
+// Steps returns the times from Begin up to, but not including, End at
+// the given resolution.  The nth time is Begin advanced by n steps, so
+// stepping by months from the 31st follows the normalization of
+// time.AddDate.
+func (ts TimeSpan) Steps(step Step) []time.Time {
+	times := make([]time.Time, 0)
+
+	for i := 0; ; i++ {
+		t := step.advance(ts.Begin, i)
+
+		if !t.Before(ts.End) {
+			break
+		}
+
+		if i > 0 && !t.After(times[i-1]) {
+			break
+		}
 
-	for i := 0; i < numHours; i++ {
-		times[i] = begin.Add(time.Duration(i) * time.Hour)
+		times = append(times, t)
 	}
 
 	return times
 }
+
+// Spans divides the span into consecutive spans one step long.  The
+// last span is cut short at End.
+func (ts TimeSpan) Spans(step Step) []TimeSpan {
+	times := ts.Steps(step)
+	spans := make([]TimeSpan, len(times))
+
+	for i, t := range times {
+		end := ts.End
+
+		if i+1 < len(times) {
+			end = times[i+1]
+		}
+
+		spans[i] = TimeSpan{Begin: t, End: end}
+	}
+
+	return spans
+}
+
+func (s Step) advance(t time.Time, n int) time.Time {
+	return t.AddDate(0, n*s.Months, n*s.Days).Add(time.Duration(n) * s.Duration)
+}
+
+func earliest(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+
+	return a
+}
+
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+
+	return a
+}
@@ -0,0 +1,135 @@
+package noaa
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOverlapsAndIntersect(t *testing.T) {
+	begin := time.Date(2016, 3, 1, 0, 0, 0, 0, time.UTC)
+	a := TimeSpan{Begin: begin, End: begin.Add(6 * time.Hour)}
+	b := TimeSpan{Begin: begin.Add(4 * time.Hour), End: begin.Add(10 * time.Hour)}
+	c := TimeSpan{Begin: begin.Add(6 * time.Hour), End: begin.Add(8 * time.Hour)}
+
+	if !a.Overlaps(b) || a.Overlaps(c) {
+		t.Errorf("Overlaps incorrect")
+	}
+
+	if !a.Contains(begin) || a.Contains(a.End) || !a.Covers(TimeSpan{Begin: begin, End: begin.Add(time.Hour)}) || a.Covers(b) {
+		t.Errorf("Contains or Covers incorrect")
+	}
+
+	i, ok := a.Intersect(b)
+
+	if !ok || !i.Begin.Equal(b.Begin) || !i.End.Equal(a.End) || i.Duration() != 2*time.Hour {
+		t.Errorf("Intersection computed as %v %v", i, ok)
+	}
+
+	if _, ok := a.Intersect(c); ok {
+		t.Errorf("Adjacent spans should not intersect")
+	}
+
+	u, ok := a.Union(c)
+
+	if !ok || !u.Begin.Equal(a.Begin) || !u.End.Equal(c.End) {
+		t.Errorf("Union computed as %v %v", u, ok)
+	}
+
+	if _, ok := a.Union(TimeSpan{Begin: begin.Add(7 * time.Hour), End: begin.Add(8 * time.Hour)}); ok {
+		t.Errorf("Spans with a gap should not have a union")
+	}
+}
+
+func TestSplit(t *testing.T) {
+	begin := time.Date(2016, 3, 1, 0, 0, 0, 0, time.UTC)
+	ts := TimeSpan{Begin: begin, End: begin.Add(10 * time.Hour)}
+	spans := ts.Split(3*time.Hour, time.Hour)
+
+	if len(spans) != 3 || spans[1].Begin != begin.Add(4*time.Hour) || spans[2].End != ts.End || spans[2].Duration() != 2*time.Hour {
+		t.Errorf("Split into %v", spans)
+	}
+
+	if len(ts.Split(0, 0)) != 0 {
+		t.Errorf("Zero duration split should be empty")
+	}
+}
+
+func TestSteps(t *testing.T) {
+	denver, err := time.LoadLocation("America/Denver")
+
+	if err != nil {
+		t.Skipf("%s", err)
+	}
+
+	// DST began at 2am on March 13th, 2016
+	begin := time.Date(2016, 3, 12, 6, 0, 0, 0, denver)
+	ts := TimeSpan{Begin: begin, End: begin.AddDate(0, 0, 3)}
+	days := ts.Steps(Day)
+
+	if len(days) != 3 || days[1].Hour() != 6 || days[2].Sub(days[1]) != 24*time.Hour || days[1].Sub(days[0]) != 23*time.Hour {
+		t.Errorf("Days stepped as %v", days)
+	}
+
+	if len(ts.Steps(Hour)) != 71 || len(ts.Steps(Minute)) != 71*60 {
+		t.Errorf("Stepped %d hours across DST", len(ts.Steps(Hour)))
+	}
+
+	year := TimeSpan{Begin: time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC), End: time.Date(2016, 12, 15, 0, 0, 0, 0, time.UTC)}
+	months := year.Spans(Month)
+
+	if len(months) != 12 || months[1].Duration() != 29*24*time.Hour || months[11].End != year.End {
+		t.Errorf("Months spanned as %v", months)
+	}
+
+	if len(year.Steps(Step{})) != 1 {
+		t.Errorf("Empty step should not repeat")
+	}
+}
+
+func TestHoursWith(t *testing.T) {
+	day := time.Date(2016, 3, 1, 0, 0, 0, 0, time.UTC)
+	ts := TimeSpan{Begin: day.Add(31 * time.Minute), End: day.Add(2*time.Hour + 29*time.Minute)}
+	tests := []struct {
+		opts  HourOptions
+		hours []int
+	}{
+		{LegacyHours, []int{1}},
+		{HalfOpenHours, []int{1, 2}},
+		{OverlappingHours, []int{0, 1, 2}},
+		{HourOptions{RoundFloor, RoundFloor, true, false}, []int{0, 1, 2}},
+		{HourOptions{RoundNearest, RoundNearest, true, false}, []int{1, 2}},
+	}
+
+	for _, test := range tests {
+		hours := ts.HoursWith(test.opts)
+
+		if len(hours) != len(test.hours) {
+			t.Errorf("%+v enumerated %v", test.opts, hours)
+			continue
+		}
+
+		for i, h := range hours {
+			if h.Hour() != test.hours[i] || h.Minute() != 0 {
+				t.Errorf("%+v enumerated %v", test.opts, hours)
+			}
+		}
+	}
+
+	short := TimeSpan{Begin: day.Add(10 * time.Minute), End: day.Add(20 * time.Minute)}
+
+	if len(short.Hours()) != 1 || len(short.HoursWith(HalfOpenHours)) != 0 {
+		t.Errorf("Short span enumerated as %v and %v", short.Hours(), short.HoursWith(HalfOpenHours))
+	}
+
+	instant := TimeSpan{Begin: day, End: day}
+
+	if len(instant.Hours()) != 1 || len(instant.HoursWith(HourOptions{RoundFloor, RoundFloor, true, false})) != 1 {
+		t.Errorf("Instant enumerated as %v", instant.Hours())
+	}
+
+	local := TimeSpan{Begin: time.Date(2016, 3, 1, 17, 0, 0, 0, time.FixedZone("MST", -7*3600)), End: time.Date(2016, 3, 1, 20, 0, 0, 0, time.FixedZone("MST", -7*3600))}
+
+	if hours := local.Hours(); len(hours) != 3 || hours[0] != time.Date(2016, 3, 2, 0, 0, 0, 0, time.UTC) {
+		t.Errorf("Local span enumerated as %v", hours)
+	}
+}
@@ -0,0 +1,298 @@
+package noaa
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Aggregation is how the samples of a Series that fall in one period are
+// combined when it is resampled.
+type Aggregation int
+
+const (
+	Mean Aggregation = iota
+	Sum
+	Max
+	Min
+	CircularMean
+)
+
+// FillMethod is how FillGaps estimates missing samples.
+type FillMethod int
+
+const (
+	FillLinear FillMethod = iota
+	FillNearest
+	FillClimatology
+)
+
+// Climatology returns the expected value at t, in the units of the
+// Series being filled.
+type Climatology func(t time.Time) (float64, bool)
+
+// GapFill controls FillGaps.  Gaps longer than MaxGap, measured from the
+// end of the sample before the gap to the beginning of the sample after,
+// are left alone; a MaxGap of zero fills gaps of any length.
+// FillClimatology interpolates the anomalies from Climatology of the
+// samples either side of the gap.
+type GapFill struct {
+	Method      FillMethod
+	MaxGap      time.Duration
+	Climatology Climatology
+}
+
+// AggregationFor returns the usual Aggregation of v: accumulations are
+// summed, wind directions are averaged around the circle, gusts, maxima
+// and probabilities take the largest value, minima the smallest, and
+// everything else is averaged.
+func AggregationFor(v Variable) Aggregation {
+	switch v {
+	case Precipitation, Snowfall:
+		return Sum
+	case WindGust, MaxTemperature, PrecipitationProbability:
+		return Max
+	case MinTemperature:
+		return Min
+	case WindDirection:
+		return CircularMean
+	}
+
+	return Mean
+}
+
+// Resample aggregates the series into periods of step using the
+// Aggregation for its variable.
+func (s Series) Resample(step Step) (Series, error) {
+	return s.ResampleWith(step, AggregationFor(s.Variable))
+}
+
+// ResampleWith aggregates the series into periods of step, aligned to
+// the start of the hour, day or month of the first sample.  Samples are
+// placed in the period their Valid span begins in, except when summing,
+// where a sample spanning several periods is shared between them in
+// proportion to its overlap with each.  Periods with no samples are left
+// out of the result.  Samples need not be in order.
+func (s Series) ResampleWith(step Step, agg Aggregation) (Series, error) {
+	if !step.positive() {
+		return s, errors.New(fmt.Sprintf("Cannot resample with step %+v", step))
+	}
+
+	s = s.sorted()
+	resampled := s
+	resampled.Samples = []Sample{}
+
+	if len(s.Samples) == 0 {
+		return resampled, nil
+	}
+
+	span := s.Span()
+	lastBegin := s.Samples[len(s.Samples)-1].Valid.Begin
+	periods := make([]TimeSpan, 0)
+
+	for t := step.floor(span.Begin); t.Before(span.End) || !t.After(lastBegin); t = step.advance(t, 1) {
+		periods = append(periods, TimeSpan{Begin: t, End: step.advance(t, 1)})
+	}
+
+	values := make([][]float64, len(periods))
+
+	for _, sample := range s.Samples {
+		i := sort.Search(len(periods), func(i int) bool {
+			return periods[i].End.After(sample.Valid.Begin)
+		})
+
+		if agg != Sum || sample.Valid.Duration() == 0 {
+			values[i] = append(values[i], sample.Value)
+			continue
+		}
+
+		for ; i < len(periods); i++ {
+			overlap, ok := periods[i].Intersect(sample.Valid)
+
+			if !ok {
+				break
+			}
+
+			share := sample.Value * float64(overlap.Duration()) / float64(sample.Valid.Duration())
+			values[i] = append(values[i], share)
+		}
+	}
+
+	for i, vals := range values {
+		if len(vals) > 0 {
+			resampled.Samples = append(resampled.Samples, Sample{periods[i], aggregate(vals, agg)})
+		}
+	}
+
+	return resampled, nil
+}
+
+// FillGaps adds a sample at each missing step between the samples of
+// the series.  Filled samples are instantaneous when the sample before
+// the gap is, and otherwise span their step.  Gaps before the first or
+// after the last sample are not filled.
+func (s Series) FillGaps(step Step, fill GapFill) (Series, error) {
+	if !step.positive() {
+		return s, errors.New(fmt.Sprintf("Cannot fill gaps with step %+v", step))
+	}
+
+	if fill.Method == FillClimatology && fill.Climatology == nil {
+		return s, errors.New("Climatology fill requires a Climatology")
+	}
+
+	s = s.sorted()
+	filled := s
+	filled.Samples = make([]Sample, 0, len(s.Samples))
+
+	for i, before := range s.Samples {
+		filled.Samples = append(filled.Samples, before)
+
+		if i+1 == len(s.Samples) {
+			break
+		}
+
+		after := s.Samples[i+1]
+
+		if fill.MaxGap > 0 && after.Valid.Begin.Sub(before.Valid.End) > fill.MaxGap {
+			continue
+		}
+
+		for t := step.advance(before.Valid.Begin, 1); t.Before(after.Valid.Begin); t = step.advance(t, 1) {
+			v, ok := fill.estimate(s.Variable, before, after, t)
+
+			if !ok {
+				continue
+			}
+
+			valid := TimeSpan{Begin: t, End: t}
+
+			if before.Valid.Duration() > 0 {
+				valid.End = step.advance(t, 1)
+			}
+
+			filled.Samples = append(filled.Samples, Sample{valid, v})
+		}
+	}
+
+	return filled, nil
+}
+
+// DailyClimatology returns the mean value for each day of the year in
+// series, which should all be in the same units.
+func DailyClimatology(series ...Series) Climatology {
+	type day struct {
+		month time.Month
+		day   int
+	}
+
+	sums := make(map[day]float64)
+	counts := make(map[day]int)
+
+	for _, s := range series {
+		for _, sample := range s.Samples {
+			d := day{sample.Valid.Begin.Month(), sample.Valid.Begin.Day()}
+			sums[d] += sample.Value
+			counts[d]++
+		}
+	}
+
+	return func(t time.Time) (float64, bool) {
+		d := day{t.Month(), t.Day()}
+
+		if counts[d] == 0 {
+			return 0, false
+		}
+
+		return sums[d] / float64(counts[d]), true
+	}
+}
+
+func (fill GapFill) estimate(v Variable, before, after Sample, t time.Time) (float64, bool) {
+	f := float64(t.Sub(before.Valid.Begin)) / float64(after.Valid.Begin.Sub(before.Valid.Begin))
+
+	switch fill.Method {
+	case FillNearest:
+		if t.Sub(before.Valid.Begin) <= after.Valid.Begin.Sub(t) {
+			return before.Value, true
+		}
+
+		return after.Value, true
+	case FillClimatology:
+		cb, okb := fill.Climatology(before.Valid.Begin)
+		ca, oka := fill.Climatology(after.Valid.Begin)
+		ct, okt := fill.Climatology(t)
+
+		if !okb || !oka || !okt {
+			return 0, false
+		}
+
+		return ct + interpolate(before.Value-cb, after.Value-ca, f), true
+	}
+
+	if v == WindDirection {
+		return math.Mod(before.Value+f*angleBetween(before.Value, after.Value)+360, 360), true
+	}
+
+	return interpolate(before.Value, after.Value, f), true
+}
+
+func interpolate(a, b, f float64) float64 {
+	return a + f*(b-a)
+}
+
+// angleBetween returns the shortest turn in degrees from a to b.
+func angleBetween(a, b float64) float64 {
+	return math.Mod(math.Mod(b-a, 360)+540, 360) - 180
+}
+
+func aggregate(vals []float64, agg Aggregation) float64 {
+	result := vals[0]
+
+	switch agg {
+	case Sum, Mean:
+		for _, v := range vals[1:] {
+			result += v
+		}
+
+		if agg == Mean {
+			result /= float64(len(vals))
+		}
+	case Max:
+		for _, v := range vals[1:] {
+			result = math.Max(result, v)
+		}
+	case Min:
+		for _, v := range vals[1:] {
+			result = math.Min(result, v)
+		}
+	case CircularMean:
+		var x, y float64
+
+		for _, v := range vals {
+			x += math.Cos(v * math.Pi / 180)
+			y += math.Sin(v * math.Pi / 180)
+		}
+
+		result = math.Mod(math.Atan2(y, x)*180/math.Pi+360, 360)
+	}
+
+	return result
+}
+
+func (s Step) positive() bool {
+	return s.Months >= 0 && s.Days >= 0 && s.Duration >= 0 && s != Step{}
+}
+
+// floor aligns t to the start of the month, day or time step it is in.
+func (s Step) floor(t time.Time) time.Time {
+	switch {
+	case s.Months > 0:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	case s.Days > 0:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+
+	return t.Truncate(s.Duration)
+}
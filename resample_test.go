@@ -0,0 +1,159 @@
+package noaa
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestResample(t *testing.T) {
+	day := time.Date(2016, 3, 1, 0, 0, 0, 0, time.UTC)
+	hours := func(h int) time.Time { return day.Add(time.Duration(h) * time.Hour) }
+	instant := func(h int, v float64) Sample { return Sample{TimeSpan{Begin: hours(h), End: hours(h)}, v} }
+
+	precip := Series{Variable: Precipitation, Samples: []Sample{
+		{TimeSpan{Begin: hours(12), End: hours(18)}, 6},
+		{TimeSpan{Begin: hours(18), End: hours(30)}, 12},
+		{TimeSpan{Begin: hours(30), End: hours(36)}, 3},
+	}}
+	daily, err := precip.Resample(Day)
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if len(daily.Samples) != 2 || daily.Samples[0].Value != 12 || daily.Samples[1].Value != 9 || !daily.Samples[1].Valid.Begin.Equal(day.AddDate(0, 0, 1)) {
+		t.Errorf("Precipitation resampled to %+v", daily.Samples)
+	}
+
+	tests := []struct {
+		variable Variable
+		values   []float64
+		expected float64
+	}{
+		{Temperature, []float64{1, 2, 6}, 3},
+		{WindGust, []float64{5, 12, 7}, 12},
+		{MinTemperature, []float64{5, -2, 7}, -2},
+		{WindDirection, []float64{350, 10, 0}, 0},
+		{WindDirection, []float64{80, 100}, 90},
+	}
+
+	for _, test := range tests {
+		s := Series{Variable: test.variable}
+
+		for i, v := range test.values {
+			s.Samples = append(s.Samples, instant(i*3+1, v))
+		}
+
+		r, err := s.Resample(Step{Duration: 12 * time.Hour})
+
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+
+		if len(r.Samples) != 1 || math.Abs(r.Samples[0].Value-test.expected) > 1e-9 || r.Samples[0].Valid.Duration() != 12*time.Hour {
+			t.Errorf("%s %v resampled to %+v", test.variable, test.values, r.Samples)
+		}
+	}
+
+	unsorted := Series{Variable: Temperature, Samples: []Sample{instant(5, 4), instant(0, 2), instant(13, 9)}}
+	r, err := unsorted.Resample(Step{Duration: 12 * time.Hour})
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if len(r.Samples) != 2 || r.Samples[0].Value != 3 || !r.Samples[0].Valid.Begin.Equal(day) || r.Samples[1].Value != 9 {
+		t.Errorf("Unsorted samples resampled to %+v", r.Samples)
+	}
+
+	if !unsorted.Samples[0].Valid.Begin.Equal(hours(5)) {
+		t.Errorf("Resampling reordered the series' samples")
+	}
+
+	if _, err := precip.Resample(Step{}); err == nil {
+		t.Errorf("Resampled with an empty step")
+	}
+}
+
+func TestFillGaps(t *testing.T) {
+	day := time.Date(2016, 3, 1, 0, 0, 0, 0, time.UTC)
+	hours := func(h int) time.Time { return day.Add(time.Duration(h) * time.Hour) }
+	instant := func(h int, v float64) Sample { return Sample{TimeSpan{Begin: hours(h), End: hours(h)}, v} }
+
+	temp := Series{Variable: Temperature, Samples: []Sample{instant(0, 0), instant(4, 8), instant(5, 9), instant(12, 2)}}
+
+	tests := []struct {
+		fill     GapFill
+		expected []float64
+	}{
+		{GapFill{Method: FillLinear}, []float64{0, 2, 4, 6, 8, 9, 8, 7, 6, 5, 4, 3, 2}},
+		{GapFill{Method: FillNearest, MaxGap: 4 * time.Hour}, []float64{0, 0, 0, 8, 8, 9, 2}},
+	}
+
+	for _, test := range tests {
+		filled, err := temp.FillGaps(Hour, test.fill)
+
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+
+		if len(filled.Samples) != len(test.expected) {
+			t.Errorf("Filled %+v as %+v", test.fill, filled.Samples)
+			continue
+		}
+
+		for i, sample := range filled.Samples {
+			if math.Abs(sample.Value-test.expected[i]) > 1e-9 || sample.Valid.Duration() != 0 {
+				t.Errorf("Filled %+v as %+v", test.fill, filled.Samples)
+				break
+			}
+		}
+	}
+
+	dir := Series{Variable: WindDirection, Samples: []Sample{instant(0, 340), instant(4, 20)}}
+	filled, _ := dir.FillGaps(Hour, GapFill{})
+
+	if len(filled.Samples) != 5 || math.Abs(filled.Samples[1].Value-350) > 1e-9 || math.Abs(filled.Samples[2].Value) > 1e-9 {
+		t.Errorf("Wind direction filled as %+v", filled.Samples)
+	}
+
+	if _, err := temp.FillGaps(Hour, GapFill{Method: FillClimatology}); err == nil {
+		t.Errorf("Climatology fill without a Climatology")
+	}
+}
+
+func TestFillClimatology(t *testing.T) {
+	begin := time.Date(2015, 3, 1, 0, 0, 0, 0, time.UTC)
+	daily := func(d int, v float64) Sample {
+		b := begin.AddDate(1, 0, d)
+		return Sample{TimeSpan{Begin: b, End: b.AddDate(0, 0, 1)}, v}
+	}
+
+	history := Series{Variable: MaxTemperature}
+
+	for d, v := range []float64{10, 20, 10, 20} {
+		b := begin.AddDate(0, 0, d)
+		history.Samples = append(history.Samples, Sample{TimeSpan{Begin: b, End: b.AddDate(0, 0, 1)}, v})
+	}
+
+	s := Series{Variable: MaxTemperature, Samples: []Sample{daily(0, 12), daily(3, 24)}}
+	filled, err := s.FillGaps(Day, GapFill{Method: FillClimatology, Climatology: DailyClimatology(history)})
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	expected := []float64{12, 22 + 2.0/3, 13 + 1.0/3, 24}
+
+	if len(filled.Samples) != len(expected) {
+		t.Fatalf("Filled as %+v", filled.Samples)
+	}
+
+	for i, sample := range filled.Samples {
+		if math.Abs(sample.Value-expected[i]) > 1e-9 || sample.Valid.Duration() != 24*time.Hour {
+			t.Errorf("Filled as %+v", filled.Samples)
+			break
+		}
+	}
+}
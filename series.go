@@ -0,0 +1,176 @@
+package noaa
+
+import (
+	"github.com/gershwinlabs/noaa/units"
+	"sort"
+	"time"
+)
+
+// Variable is the canonical name of a quantity, shared by observations
+// and forecasts whatever their source calls it.
+type Variable string
+
+const (
+	Temperature              Variable = "temperature"
+	MaxTemperature           Variable = "max_temperature"
+	MinTemperature           Variable = "min_temperature"
+	DewPoint                 Variable = "dew_point"
+	RelativeHumidity         Variable = "relative_humidity"
+	Precipitation            Variable = "precipitation"
+	PrecipitationProbability Variable = "precipitation_probability"
+	Snowfall                 Variable = "snowfall"
+	SnowDepth                Variable = "snow_depth"
+	WindSpeed                Variable = "wind_speed"
+	WindGust                 Variable = "wind_gust"
+	WindDirection            Variable = "wind_direction"
+	CloudCover               Variable = "cloud_cover"
+)
+
+type ProvenanceKind int
+
+const (
+	Observed ProvenanceKind = iota
+	Forecast
+)
+
+// Provenance records where a value came from.  Issued is when a forecast
+// was made, and is zero for observations.
+type Provenance struct {
+	Kind   ProvenanceKind
+	Source string
+	Issued time.Time
+}
+
+// Location identifies where a value applies, by station or point ID
+// and coordinates when they are known.
+type Location struct {
+	ID  string
+	Lat float64
+	Lon float64
+}
+
+// Datum is a single value of a Variable at a Location over the Valid
+// span.  Instantaneous values have an equal Begin and End.
+type Datum struct {
+	Variable   Variable
+	Location   Location
+	Valid      TimeSpan
+	Value      float64
+	Unit       units.Unit
+	Provenance Provenance
+}
+
+type Sample struct {
+	Valid TimeSpan
+	Value float64
+}
+
+// Series is a run of values of one Variable at one Location from one
+// source, ordered by the beginning of their Valid spans.
+type Series struct {
+	Variable   Variable
+	Location   Location
+	Unit       units.Unit
+	Provenance Provenance
+	Samples    []Sample
+}
+
+func (v ProvenanceKind) String() string {
+	if v == Forecast {
+		return "forecast"
+	}
+
+	return "observed"
+}
+
+// NewSeries groups data into a Series for each variable, location,
+// unit and provenance, in the order each is first seen.
+func NewSeries(data []Datum) []Series {
+	type seriesKey struct {
+		variable   Variable
+		location   Location
+		unit       units.Unit
+		provenance Provenance
+	}
+
+	index := make(map[seriesKey]int)
+	series := make([]Series, 0)
+
+	for _, d := range data {
+		key := seriesKey{d.Variable, d.Location, d.Unit, d.Provenance}
+		i, ok := index[key]
+
+		if !ok {
+			i = len(series)
+			index[key] = i
+			series = append(series, Series{d.Variable, d.Location, d.Unit, d.Provenance, []Sample{}})
+		}
+
+		series[i].Samples = append(series[i].Samples, Sample{d.Valid, d.Value})
+	}
+
+	for _, s := range series {
+		s.sort()
+	}
+
+	return series
+}
+
+func (s Series) Data() []Datum {
+	data := make([]Datum, len(s.Samples))
+
+	for i, sample := range s.Samples {
+		data[i] = Datum{s.Variable, s.Location, sample.Valid, sample.Value, s.Unit, s.Provenance}
+	}
+
+	return data
+}
+
+// ConvertTo returns a copy of the series in unit u.
+func (s Series) ConvertTo(u units.Unit) (Series, error) {
+	converted := s
+	converted.Unit = u
+	converted.Samples = make([]Sample, len(s.Samples))
+
+	for i, sample := range s.Samples {
+		v, err := units.Convert(sample.Value, s.Unit, u)
+
+		if err != nil {
+			return s, err
+		}
+
+		converted.Samples[i] = Sample{sample.Valid, v}
+	}
+
+	return converted, nil
+}
+
+// Span returns the span from the beginning of the first sample to the
+// end of the last.
+func (s Series) Span() TimeSpan {
+	if len(s.Samples) == 0 {
+		return TimeSpan{}
+	}
+
+	span := s.Samples[0].Valid
+
+	for _, sample := range s.Samples[1:] {
+		span.End = latest(span.End, sample.Valid.End)
+	}
+
+	return span
+}
+
+// sorted returns a copy of the series with its samples in order, leaving
+// the samples of s as they were.
+func (s Series) sorted() Series {
+	s.Samples = append([]Sample{}, s.Samples...)
+	s.sort()
+	return s
+}
+
+func (s Series) sort() {
+	sort.SliceStable(s.Samples, func(i, j int) bool {
+		return s.Samples[i].Valid.Begin.Before(s.Samples[j].Valid.Begin)
+	})
+}
@@ -0,0 +1,47 @@
+package noaa
+
+import (
+	"github.com/gershwinlabs/noaa/units"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestNewSeries(t *testing.T) {
+	day := time.Date(2016, 3, 1, 0, 0, 0, 0, time.UTC)
+	loc := Location{"GHCND:USW00094728", 40.78, -73.97}
+	observed := Provenance{Kind: Observed, Source: "CDO GHCND"}
+	data := []Datum{
+		{MaxTemperature, loc, TimeSpan{Begin: day.AddDate(0, 0, 1), End: day.AddDate(0, 0, 2)}, 10, units.Celsius, observed},
+		{MaxTemperature, loc, TimeSpan{Begin: day, End: day.AddDate(0, 0, 1)}, 5, units.Celsius, observed},
+		{Precipitation, loc, TimeSpan{Begin: day, End: day.AddDate(0, 0, 1)}, 2.5, units.Millimeters, observed},
+	}
+
+	series := NewSeries(data)
+
+	if len(series) != 2 || series[0].Variable != MaxTemperature || len(series[0].Samples) != 2 {
+		t.Fatalf("Unexpected series %+v", series)
+	}
+
+	if series[0].Samples[0].Value != 5 || !series[0].Span().End.Equal(day.AddDate(0, 0, 2)) {
+		t.Errorf("Samples out of order: %+v", series[0].Samples)
+	}
+
+	f, err := series[0].ConvertTo(units.Fahrenheit)
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if math.Abs(f.Samples[1].Value-50) > 1e-9 || f.Unit != units.Fahrenheit || series[0].Samples[1].Value != 10 {
+		t.Errorf("Converted to %+v", f)
+	}
+
+	if d := series[1].Data(); len(d) != 1 || d[0] != data[2] {
+		t.Errorf("Data returned as %+v", d)
+	}
+
+	if _, err := series[1].ConvertTo(units.Celsius); err == nil {
+		t.Errorf("Precipitation should not convert to Celsius")
+	}
+}
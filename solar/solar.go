@@ -0,0 +1,294 @@
+package solar
+
+import (
+	"github.com/gershwinlabs/noaa"
+	"math"
+	"time"
+)
+
+// Zenith angles in degrees of the sun at sunrise and sunset and at the
+// ends of each twilight.  Official allows for refraction and the radius
+// of the sun.
+const (
+	Official     = 90.833
+	Civil        = 96.0
+	Nautical     = 102.0
+	Astronomical = 108.0
+)
+
+// Position is where the sun is in the sky.  Elevation is corrected for
+// atmospheric refraction and Zenith is its complement.  Azimuth is in
+// degrees clockwise from north.
+type Position struct {
+	Elevation      float64
+	Zenith         float64
+	Azimuth        float64
+	Declination    float64
+	EquationOfTime time.Duration
+}
+
+// Day holds the solar events of one day.  Events that do not happen,
+// such as sunset during the polar summer, are zero.
+type Day struct {
+	SolarNoon        time.Time
+	Sunrise          time.Time
+	Sunset           time.Time
+	CivilDawn        time.Time
+	CivilDusk        time.Time
+	NauticalDawn     time.Time
+	NauticalDusk     time.Time
+	AstronomicalDawn time.Time
+	AstronomicalDusk time.Time
+}
+
+// sun holds the declination of the sun in degrees and the equation of
+// time in minutes.
+type sun struct {
+	declination float64
+	eot         float64
+}
+
+func rad(deg float64) float64 {
+	return deg * math.Pi / 180
+}
+
+func deg(rad float64) float64 {
+	return rad * 180 / math.Pi
+}
+
+// sunAt follows the equations of the NOAA solar calculator, which are
+// based on Meeus' Astronomical Algorithms.
+func sunAt(t time.Time) sun {
+	jd := float64(t.UnixNano())/float64(24*time.Hour) + 2440587.5
+	c := (jd - 2451545) / 36525
+
+	meanLong := math.Mod(280.46646+c*(36000.76983+c*0.0003032), 360)
+	meanAnom := 357.52911 + c*(35999.05029-0.0001537*c)
+	eccent := 0.016708634 - c*(0.000042037+0.0000001267*c)
+	center := math.Sin(rad(meanAnom))*(1.914602-c*(0.004817+0.000014*c)) +
+		math.Sin(rad(2*meanAnom))*(0.019993-0.000101*c) +
+		math.Sin(rad(3*meanAnom))*0.000289
+	omega := 125.04 - 1934.136*c
+	appLong := meanLong + center - 0.00569 - 0.00478*math.Sin(rad(omega))
+	meanObliq := 23 + (26+(21.448-c*(46.815+c*(0.00059-c*0.001813)))/60)/60
+	obliq := meanObliq + 0.00256*math.Cos(rad(omega))
+	y := math.Pow(math.Tan(rad(obliq/2)), 2)
+
+	eot := y*math.Sin(2*rad(meanLong)) -
+		2*eccent*math.Sin(rad(meanAnom)) +
+		4*eccent*y*math.Sin(rad(meanAnom))*math.Cos(2*rad(meanLong)) -
+		0.5*y*y*math.Sin(4*rad(meanLong)) -
+		1.25*eccent*eccent*math.Sin(2*rad(meanAnom))
+
+	return sun{deg(math.Asin(math.Sin(rad(obliq)) * math.Sin(rad(appLong)))), 4 * deg(eot)}
+}
+
+// hourAngle returns the hour angle of the sun at t in degrees, from -180
+// up to 180, which is zero at solar noon and negative in the morning.
+func hourAngle(lon float64, t time.Time, s sun) float64 {
+	u := t.UTC()
+	midnight := time.Date(u.Year(), u.Month(), u.Day(), 0, 0, 0, 0, time.UTC)
+	minutes := u.Sub(midnight).Minutes()
+	return math.Mod(math.Mod((minutes+s.eot+4*lon)/4, 360)+360, 360) - 180
+}
+
+// whenHourAngle returns the time near t when the sun reaches the hour
+// angle returned by target, refining it as the sun moves.
+func whenHourAngle(lon float64, t time.Time, target func(s sun) (float64, bool)) (time.Time, bool) {
+	for i := 0; i < 3; i++ {
+		s := sunAt(t)
+		ha, ok := target(s)
+
+		if !ok {
+			return time.Time{}, false
+		}
+
+		diff := math.Mod(math.Mod(ha-hourAngle(lon, t, s), 360)+540, 360) - 180
+		t = t.Add(time.Duration(diff * 4 * float64(time.Minute)))
+	}
+
+	return t, true
+}
+
+// SolarNoon returns the time on date, in its location, when the sun
+// crosses the meridian at lon.
+func SolarNoon(lon float64, date time.Time) time.Time {
+	noon := time.Date(date.Year(), date.Month(), date.Day(), 12, 0, 0, 0, date.Location())
+	t, _ := whenHourAngle(lon, noon, func(s sun) (float64, bool) { return 0, true })
+	return t.Round(time.Second).In(date.Location())
+}
+
+// Rise returns when the sun rises through the zenith angle on date,
+// which is false when it does not that day.
+func Rise(lat, lon float64, date time.Time, zenith float64) (time.Time, bool) {
+	return event(lat, lon, date, zenith, -1)
+}
+
+// Set returns when the sun sets through the zenith angle on date, which
+// is false when it does not that day.
+func Set(lat, lon float64, date time.Time, zenith float64) (time.Time, bool) {
+	return event(lat, lon, date, zenith, 1)
+}
+
+func event(lat, lon float64, date time.Time, zenith, sign float64) (time.Time, bool) {
+	t, ok := whenHourAngle(lon, SolarNoon(lon, date), func(s sun) (float64, bool) {
+		ha, ok := eventHourAngle(lat, s.declination, zenith)
+		return sign * ha, ok
+	})
+
+	if !ok {
+		return t, false
+	}
+
+	return t.Round(time.Second).In(date.Location()), true
+}
+
+// eventHourAngle returns the hour angle at which the sun is at zenith,
+// which is false when the sun is always above or always below it.
+func eventHourAngle(lat, declination, zenith float64) (float64, bool) {
+	cosHA := math.Cos(rad(zenith))/(math.Cos(rad(lat))*math.Cos(rad(declination))) -
+		math.Tan(rad(lat))*math.Tan(rad(declination))
+
+	if cosHA < -1 || cosHA > 1 {
+		return 0, false
+	}
+
+	return deg(math.Acos(cosHA)), true
+}
+
+// Events returns the solar noon, sunrise, sunset and twilights on date.
+func Events(lat, lon float64, date time.Time) Day {
+	day := Day{SolarNoon: SolarNoon(lon, date)}
+	day.Sunrise, _ = Rise(lat, lon, date, Official)
+	day.Sunset, _ = Set(lat, lon, date, Official)
+	day.CivilDawn, _ = Rise(lat, lon, date, Civil)
+	day.CivilDusk, _ = Set(lat, lon, date, Civil)
+	day.NauticalDawn, _ = Rise(lat, lon, date, Nautical)
+	day.NauticalDusk, _ = Set(lat, lon, date, Nautical)
+	day.AstronomicalDawn, _ = Rise(lat, lon, date, Astronomical)
+	day.AstronomicalDusk, _ = Set(lat, lon, date, Astronomical)
+	return day
+}
+
+// PositionAt returns the position of the sun at t seen from lat, lon.
+func PositionAt(lat, lon float64, t time.Time) Position {
+	s := sunAt(t)
+	ha := hourAngle(lon, t, s)
+	zenith := geometricZenith(lat, s.declination, ha)
+	elevation := 90 - zenith
+
+	azCos := (math.Sin(rad(lat))*math.Cos(rad(zenith)) - math.Sin(rad(s.declination))) /
+		(math.Cos(rad(lat)) * math.Sin(rad(zenith)))
+	az := deg(math.Acos(math.Max(-1, math.Min(1, azCos))))
+
+	if ha > 0 {
+		az = math.Mod(az+180, 360)
+	} else {
+		az = math.Mod(540-az, 360)
+	}
+
+	elevation += refraction(elevation)
+
+	return Position{
+		Elevation:      elevation,
+		Zenith:         90 - elevation,
+		Azimuth:        az,
+		Declination:    s.declination,
+		EquationOfTime: time.Duration(s.eot * float64(time.Minute)),
+	}
+}
+
+func geometricZenith(lat, declination, ha float64) float64 {
+	cosZenith := math.Sin(rad(lat))*math.Sin(rad(declination)) +
+		math.Cos(rad(lat))*math.Cos(rad(declination))*math.Cos(rad(ha))
+	return deg(math.Acos(math.Max(-1, math.Min(1, cosZenith))))
+}
+
+// refraction approximates atmospheric refraction in degrees at the
+// given elevation, as the NOAA solar calculator does.
+func refraction(elevation float64) float64 {
+	te := math.Tan(rad(elevation))
+	var arcsec float64
+
+	switch {
+	case elevation > 85:
+		return 0
+	case elevation > 5:
+		arcsec = 58.1/te - 0.07/math.Pow(te, 3) + 0.000086/math.Pow(te, 5)
+	case elevation > -0.575:
+		arcsec = 1735 + elevation*(-518.2+elevation*(103.4+elevation*(-12.79+elevation*0.711)))
+	default:
+		arcsec = -20.772 / te
+	}
+
+	return arcsec / 3600
+}
+
+// IsDaylight returns whether the sun is up at t, between the official
+// sunrise and sunset.
+func IsDaylight(lat, lon float64, t time.Time) bool {
+	s := sunAt(t)
+	return geometricZenith(lat, s.declination, hourAngle(lon, t, s)) < Official
+}
+
+// Daylight returns the spans of ts between sunrise and sunset.
+func Daylight(lat, lon float64, ts noaa.TimeSpan) []noaa.TimeSpan {
+	spans := make([]noaa.TimeSpan, 0)
+	begin := ts.Begin.AddDate(0, 0, -1)
+
+	for date := begin; !date.After(ts.End.AddDate(0, 0, 1)); date = date.AddDate(0, 0, 1) {
+		day, ok := daylight(lat, lon, date)
+
+		if !ok {
+			continue
+		}
+
+		day, ok = day.Intersect(ts)
+
+		if !ok {
+			continue
+		}
+
+		if n := len(spans); n > 0 {
+			if u, ok := spans[n-1].Union(day); ok {
+				spans[n-1] = u
+				continue
+			}
+		}
+
+		spans = append(spans, day)
+	}
+
+	return spans
+}
+
+// daylight returns the span from sunrise to sunset on date.  During the
+// polar summer it is the whole of date.
+func daylight(lat, lon float64, date time.Time) (noaa.TimeSpan, bool) {
+	rise, okRise := Rise(lat, lon, date, Official)
+	set, okSet := Set(lat, lon, date, Official)
+
+	switch {
+	case okRise && okSet:
+		return noaa.TimeSpan{Begin: rise, End: set}, true
+	case IsDaylight(lat, lon, SolarNoon(lon, date)):
+		midnight := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+		return noaa.TimeSpan{Begin: midnight, End: midnight.AddDate(0, 0, 1)}, true
+	}
+
+	return noaa.TimeSpan{}, false
+}
+
+// DaylightHours returns the whole hours of ts when the sun is up, such
+// as those of the hourly NDFD Conditions to flag as daylight.
+func DaylightHours(lat, lon float64, ts noaa.TimeSpan) []time.Time {
+	hours := make([]time.Time, 0)
+
+	for _, h := range ts.HoursWith(noaa.HalfOpenHours) {
+		if IsDaylight(lat, lon, h) {
+			hours = append(hours, h)
+		}
+	}
+
+	return hours
+}
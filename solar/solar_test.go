@@ -0,0 +1,114 @@
+package solar
+
+import (
+	"github.com/gershwinlabs/noaa"
+	"math"
+	"testing"
+	"time"
+)
+
+func near(a, b time.Time, within time.Duration) bool {
+	d := a.Sub(b)
+	return d < within && d > -within
+}
+
+func TestEvents(t *testing.T) {
+	london, _ := time.LoadLocation("Europe/London")
+	newYork, _ := time.LoadLocation("America/New_York")
+
+	tests := []struct {
+		name    string
+		lat     float64
+		lon     float64
+		date    time.Time
+		noon    time.Time
+		sunrise time.Time
+		sunset  time.Time
+	}{
+		{
+			"Greenwich midsummer", 51.4769, -0.0005, time.Date(2016, 6, 21, 0, 0, 0, 0, london),
+			time.Date(2016, 6, 21, 13, 1, 45, 0, london),
+			time.Date(2016, 6, 21, 4, 43, 0, 0, london),
+			time.Date(2016, 6, 21, 21, 21, 0, 0, london),
+		},
+		{
+			"New York midwinter", 40.7128, -74.006, time.Date(2016, 12, 21, 0, 0, 0, 0, newYork),
+			time.Date(2016, 12, 21, 11, 54, 0, 0, newYork),
+			time.Date(2016, 12, 21, 7, 16, 0, 0, newYork),
+			time.Date(2016, 12, 21, 16, 32, 0, 0, newYork),
+		},
+	}
+
+	for _, test := range tests {
+		day := Events(test.lat, test.lon, test.date)
+
+		if !near(day.SolarNoon, test.noon, time.Minute) || !near(day.Sunrise, test.sunrise, 2*time.Minute) || !near(day.Sunset, test.sunset, 2*time.Minute) {
+			t.Errorf("%s: %+v", test.name, day)
+		}
+
+		if day.SolarNoon.Location() != test.date.Location() {
+			t.Errorf("%s: events in %s", test.name, day.SolarNoon.Location())
+		}
+
+		if !(day.CivilDawn.Before(day.Sunrise) && day.NauticalDawn.Before(day.CivilDawn) && day.Sunset.Before(day.CivilDusk) && day.CivilDusk.Before(day.NauticalDusk)) {
+			t.Errorf("%s: twilights out of order %+v", test.name, day)
+		}
+	}
+
+	day := Events(51.4769, -0.0005, time.Date(2016, 6, 21, 0, 0, 0, 0, london))
+
+	if !day.AstronomicalDawn.IsZero() || !day.AstronomicalDusk.IsZero() {
+		t.Errorf("London has no astronomical night at midsummer: %+v", day)
+	}
+
+	if _, ok := Set(69.65, 18.96, time.Date(2016, 6, 21, 0, 0, 0, 0, time.UTC), Official); ok {
+		t.Errorf("The sun sets at Tromsø at midsummer")
+	}
+
+	if _, ok := Rise(69.65, 18.96, time.Date(2016, 12, 21, 0, 0, 0, 0, time.UTC), Official); ok {
+		t.Errorf("The sun rises at Tromsø at midwinter")
+	}
+}
+
+func TestPositionAt(t *testing.T) {
+	noon := SolarNoon(-0.0005, time.Date(2016, 6, 21, 0, 0, 0, 0, time.UTC))
+	p := PositionAt(51.4769, -0.0005, noon)
+
+	if math.Abs(p.Elevation-61.97) > 0.05 || math.Abs(p.Azimuth-180) > 0.5 || math.Abs(p.Declination-23.43) > 0.05 || p.EquationOfTime > -time.Minute {
+		t.Errorf("Midsummer noon position %+v", p)
+	}
+
+	morning := PositionAt(51.4769, -0.0005, noon.Add(-6*time.Hour))
+
+	if morning.Azimuth < 45 || morning.Azimuth > 135 || morning.Zenith != 90-morning.Elevation {
+		t.Errorf("Midsummer morning position %+v", morning)
+	}
+
+	equinox := PositionAt(0, 0, time.Date(2016, 3, 20, 4, 30, 0, 0, time.UTC))
+
+	if math.Abs(equinox.Declination) > 0.01 {
+		t.Errorf("Equinox declination %v", equinox.Declination)
+	}
+}
+
+func TestDaylight(t *testing.T) {
+	begin := time.Date(2016, 3, 1, 0, 0, 0, 0, time.UTC)
+	ts := noaa.TimeSpan{Begin: begin, End: begin.AddDate(0, 0, 2)}
+	spans := Daylight(39.64, -106.37, ts)
+
+	if len(spans) != 3 || !spans[0].Begin.Equal(begin) || spans[1].Duration() < 11*time.Hour || spans[1].Duration() > 12*time.Hour {
+		t.Errorf("Daylight spans %v", spans)
+	}
+
+	hours := DaylightHours(39.64, -106.37, noaa.TimeSpan{Begin: begin, End: begin.AddDate(0, 0, 1)})
+
+	if len(hours) != 11 || hours[0].Hour() != 0 || hours[1].Hour() != 14 || !IsDaylight(39.64, -106.37, hours[3]) {
+		t.Errorf("Daylight hours %v", hours)
+	}
+
+	polar := Daylight(69.65, 18.96, noaa.TimeSpan{Begin: time.Date(2016, 6, 20, 0, 0, 0, 0, time.UTC), End: time.Date(2016, 6, 23, 0, 0, 0, 0, time.UTC)})
+
+	if len(polar) != 1 || polar[0].Duration() != 72*time.Hour {
+		t.Errorf("Polar daylight spans %v", polar)
+	}
+}
@@ -0,0 +1,110 @@
+package units
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Temperature, Length, Speed, Pressure, Direction and Percentage hold a
+// value of their quantity in a fixed base unit, so values in different
+// units can be compared and combined safely.  Create them from a value
+// and its unit, and read them back with In.
+
+// Temperature is an absolute temperature in Kelvin.
+type Temperature float64
+
+// Length is a length or depth in meters.
+type Length float64
+
+// Speed is a speed in meters per second.
+type Speed float64
+
+// Pressure is a pressure in pascals.
+type Pressure float64
+
+// Direction is a compass direction in degrees clockwise from true north,
+// from 0 up to 360.
+type Direction float64
+
+// Percentage is a ratio in percent.
+type Percentage float64
+
+var compassPoints = []string{"N", "NNE", "NE", "ENE", "E", "ESE", "SE", "SSE", "S", "SSW", "SW", "WSW", "W", "WNW", "NW", "NNW"}
+
+func NewTemperature(v float64, u Unit) (Temperature, error) {
+	k, err := toBase(v, u, Kelvin)
+	return Temperature(k), err
+}
+
+func NewLength(v float64, u Unit) (Length, error) {
+	m, err := toBase(v, u, Meters)
+	return Length(m), err
+}
+
+func NewSpeed(v float64, u Unit) (Speed, error) {
+	ms, err := toBase(v, u, MetersPerSecond)
+	return Speed(ms), err
+}
+
+func NewPressure(v float64, u Unit) (Pressure, error) {
+	pa, err := toBase(v, u, Pascals)
+	return Pressure(pa), err
+}
+
+// NewDirection normalizes the direction to be from 0 up to 360 degrees.
+func NewDirection(v float64, u Unit) (Direction, error) {
+	deg, err := toBase(v, u, Degrees)
+	return Direction(math.Mod(math.Mod(deg, 360)+360, 360)), err
+}
+
+func NewPercentage(v float64, u Unit) (Percentage, error) {
+	p, err := toBase(v, u, Percent)
+	return Percentage(p), err
+}
+
+func (t Temperature) In(u Unit) (float64, error) {
+	return fromBase(float64(t), Kelvin, u)
+}
+
+func (l Length) In(u Unit) (float64, error) {
+	return fromBase(float64(l), Meters, u)
+}
+
+func (s Speed) In(u Unit) (float64, error) {
+	return fromBase(float64(s), MetersPerSecond, u)
+}
+
+func (p Pressure) In(u Unit) (float64, error) {
+	return fromBase(float64(p), Pascals, u)
+}
+
+func (d Direction) In(u Unit) (float64, error) {
+	return fromBase(float64(d), Degrees, u)
+}
+
+func (p Percentage) In(u Unit) (float64, error) {
+	return fromBase(float64(p), Percent, u)
+}
+
+// Cardinal returns the nearest of the 16 points of the compass, such as
+// "N" or "WSW".
+func (d Direction) Cardinal() string {
+	return compassPoints[int(math.Round(float64(d)/22.5))%len(compassPoints)]
+}
+
+func toBase(v float64, u, base Unit) (float64, error) {
+	if u.Kind() != base.Kind() {
+		return 0, errors.New(fmt.Sprintf("%s is not a unit of %s", u, base.Kind()))
+	}
+
+	return Convert(v, u, base)
+}
+
+func fromBase(v float64, base, u Unit) (float64, error) {
+	if u.Kind() != base.Kind() {
+		return 0, errors.New(fmt.Sprintf("%s is not a unit of %s", u, base.Kind()))
+	}
+
+	return Convert(v, base, u)
+}
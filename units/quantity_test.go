@@ -0,0 +1,114 @@
+package units
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQuantities(t *testing.T) {
+	temp, err := NewTemperature(68, Fahrenheit)
+
+	if c, _ := temp.In(Celsius); err != nil || math.Abs(c-20) > 1e-9 {
+		t.Errorf("68 Fahrenheit is %v Celsius %v", c, err)
+	}
+
+	depth, _ := NewLength(10, Inches)
+
+	if cm, _ := depth.In(Centimeters); math.Abs(cm-25.4) > 1e-9 {
+		t.Errorf("10 inches is %v centimeters", cm)
+	}
+
+	wind, _ := NewSpeed(20, Knots)
+
+	if ms, _ := wind.In(MetersPerSecond); math.Abs(ms-10.288888889) > 1e-6 {
+		t.Errorf("20 knots is %v meters/second", ms)
+	}
+
+	pressure, _ := NewPressure(1013.25, Millibars)
+
+	if inHg, _ := pressure.In(InchesOfMercury); math.Abs(inHg-29.921) > 1e-3 {
+		t.Errorf("1013.25 millibars is %v inches of mercury", inHg)
+	}
+
+	dir, _ := NewDirection(-22.5, Degrees)
+
+	if dir != 337.5 || dir.Cardinal() != "NNW" {
+		t.Errorf("-22.5 degrees is %v %s", dir, dir.Cardinal())
+	}
+
+	if d, _ := NewDirection(359, Degrees); d.Cardinal() != "N" {
+		t.Errorf("359 degrees is %s", d.Cardinal())
+	}
+
+	sky, _ := NewPercentage(0.25, Fraction)
+
+	if sky != 25 {
+		t.Errorf("0.25 is %v percent", sky)
+	}
+
+	if _, err := NewTemperature(10, Knots); err == nil {
+		t.Errorf("Knots should not be a temperature")
+	}
+
+	if _, err := wind.In(Celsius); err == nil {
+		t.Errorf("Speed should not be in Celsius")
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for u := range unitKinds {
+		parsed, err := Parse(u.String())
+
+		if err != nil || parsed != u {
+			t.Errorf("%s parsed as %s %v", u, parsed, err)
+		}
+
+		for to, kind := range unitKinds {
+			if kind != u.Kind() || kind == KindCategory {
+				continue
+			}
+
+			for _, v := range []float64{-40, 0, 0.1, 12.5, 1013.25} {
+				converted, err := Convert(v, u, to)
+
+				if err != nil {
+					t.Errorf("%s", err)
+					break
+				}
+
+				back, err := Convert(converted, to, u)
+
+				if err != nil || math.Abs(back-v) > 1e-9 {
+					t.Errorf("%v %s converted to %v %s and back to %v", v, u, converted, to, back)
+				}
+			}
+		}
+	}
+}
+
+func TestParseScaled(t *testing.T) {
+	tests := []struct {
+		s     string
+		unit  Unit
+		scale float64
+	}{
+		{"Tenths of degrees C", Celsius, 0.1},
+		{"tenths of mm", Millimeters, 0.1},
+		{"Tenths of meters per second", MetersPerSecond, 0.1},
+		{"hundredths of inches", Inches, 0.01},
+		{"Degrees Fahrenheit", Fahrenheit, 1},
+		{"hPa", Hectopascals, 1},
+	}
+
+	for _, test := range tests {
+		u, scale, err := ParseScaled(test.s)
+
+		if err != nil || u != test.unit || scale != test.scale {
+			t.Errorf("%q parsed as %s %v %v", test.s, u, scale, err)
+		}
+	}
+
+	if _, _, err := ParseScaled("tenths of furlongs"); err == nil {
+		t.Errorf("Unknown scaled units should not parse")
+	}
+}
@@ -0,0 +1,382 @@
+package units
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+type Unit int
+
+const (
+	Unknown Unit = iota
+	Celsius
+	Fahrenheit
+	Kelvin
+	Millimeters
+	Centimeters
+	Meters
+	Inches
+	Feet
+	MetersPerSecond
+	KilometersPerHour
+	MilesPerHour
+	Knots
+	Degrees
+	Percent
+	Category
+	Kilometers
+	Miles
+	Radians
+	Fraction
+	Pascals
+	Hectopascals
+	Kilopascals
+	Millibars
+	InchesOfMercury
+)
+
+// Kind is the physical quantity a Unit measures.  Values can only be
+// converted between units of the same Kind.
+type Kind int
+
+const (
+	KindUnknown Kind = iota
+	KindTemperature
+	KindLength
+	KindSpeed
+	KindAngle
+	KindRatio
+	KindCategory
+	KindPressure
+)
+
+// System is a system of measurement, matching the Unit parameter of
+// NDFD requests.
+type System int
+
+const (
+	Metric System = iota
+	English
+)
+
+var unitNames = map[Unit]string{
+	Unknown:           "unknown",
+	Celsius:           "Celsius",
+	Fahrenheit:        "Fahrenheit",
+	Kelvin:            "Kelvin",
+	Millimeters:       "millimeters",
+	Centimeters:       "centimeters",
+	Meters:            "meters",
+	Inches:            "inches",
+	Feet:              "feet",
+	MetersPerSecond:   "meters/second",
+	KilometersPerHour: "kilometers/hour",
+	MilesPerHour:      "miles/hour",
+	Knots:             "knots",
+	Degrees:           "degrees true",
+	Percent:           "percent",
+	Category:          "category",
+	Kilometers:        "kilometers",
+	Miles:             "statute miles",
+	Radians:           "radians",
+	Fraction:          "fraction",
+	Pascals:           "pascals",
+	Hectopascals:      "hectopascals",
+	Kilopascals:       "kilopascals",
+	Millibars:         "millibars",
+	InchesOfMercury:   "inches of mercury",
+}
+
+var kindNames = map[Kind]string{
+	KindUnknown:     "unknown",
+	KindTemperature: "temperature",
+	KindLength:      "length",
+	KindSpeed:       "speed",
+	KindAngle:       "angle",
+	KindRatio:       "ratio",
+	KindCategory:    "category",
+	KindPressure:    "pressure",
+}
+
+var unitKinds = map[Unit]Kind{
+	Celsius:           KindTemperature,
+	Fahrenheit:        KindTemperature,
+	Kelvin:            KindTemperature,
+	Millimeters:       KindLength,
+	Centimeters:       KindLength,
+	Meters:            KindLength,
+	Inches:            KindLength,
+	Feet:              KindLength,
+	MetersPerSecond:   KindSpeed,
+	KilometersPerHour: KindSpeed,
+	MilesPerHour:      KindSpeed,
+	Knots:             KindSpeed,
+	Degrees:           KindAngle,
+	Percent:           KindRatio,
+	Category:          KindCategory,
+	Kilometers:        KindLength,
+	Miles:             KindLength,
+	Radians:           KindAngle,
+	Fraction:          KindRatio,
+	Pascals:           KindPressure,
+	Hectopascals:      KindPressure,
+	Kilopascals:       KindPressure,
+	Millibars:         KindPressure,
+	InchesOfMercury:   KindPressure,
+}
+
+// aliases maps the lower case unit strings found in DWML, along with
+// common abbreviations, to their Unit.
+var aliases = map[string]Unit{
+	"celsius":            Celsius,
+	"degrees celsius":    Celsius,
+	"°c":                 Celsius,
+	"degc":               Celsius,
+	"fahrenheit":         Fahrenheit,
+	"degrees fahrenheit": Fahrenheit,
+	"°f":                 Fahrenheit,
+	"degf":               Fahrenheit,
+	"kelvin":             Kelvin,
+	"millimeters":        Millimeters,
+	"millimetres":        Millimeters,
+	"mm":                 Millimeters,
+	"centimeters":        Centimeters,
+	"centimetres":        Centimeters,
+	"cm":                 Centimeters,
+	"meters":             Meters,
+	"metres":             Meters,
+	"inches":             Inches,
+	"inch":               Inches,
+	"feet":               Feet,
+	"foot":               Feet,
+	"ft":                 Feet,
+	"meters/second":      MetersPerSecond,
+	"meters per second":  MetersPerSecond,
+	"m/s":                MetersPerSecond,
+	"kilometers/hour":    KilometersPerHour,
+	"km/h":               KilometersPerHour,
+	"kph":                KilometersPerHour,
+	"miles/hour":         MilesPerHour,
+	"miles per hour":     MilesPerHour,
+	"mph":                MilesPerHour,
+	"knots":              Knots,
+	"knot":               Knots,
+	"kt":                 Knots,
+	"kts":                Knots,
+	"degrees true":       Degrees,
+	"degrees":            Degrees,
+	"deg":                Degrees,
+	"percent":            Percent,
+	"%":                  Percent,
+	"category":           Category,
+	"kilometers":         Kilometers,
+	"kilometres":         Kilometers,
+	"km":                 Kilometers,
+	"statute miles":      Miles,
+	"miles":              Miles,
+	"mi":                 Miles,
+	"radians":            Radians,
+	"rad":                Radians,
+	"fraction":           Fraction,
+	"pascals":            Pascals,
+	"pa":                 Pascals,
+	"hectopascals":       Hectopascals,
+	"hpa":                Hectopascals,
+	"kilopascals":        Kilopascals,
+	"kpa":                Kilopascals,
+	"millibars":          Millibars,
+	"mb":                 Millibars,
+	"mbar":               Millibars,
+	"inches of mercury":  InchesOfMercury,
+	"inhg":               InchesOfMercury,
+	"in hg":              InchesOfMercury,
+	"deg c":              Celsius,
+	"degrees c":          Celsius,
+	"deg f":              Fahrenheit,
+	"degrees f":          Fahrenheit,
+	"degrees (true)":     Degrees,
+	"degrees of arc":     Degrees,
+	"meters per sec":     MetersPerSecond,
+}
+
+// scales are the prefixes CDO documentation uses for values stored as
+// integer multiples of a unit, such as GHCND's "tenths of mm".
+var scales = map[string]float64{
+	"tenths of ":      0.1,
+	"hundredths of ":  0.01,
+	"thousandths of ": 0.001,
+}
+
+func Parse(s string) (Unit, error) {
+	u, ok := aliases[strings.ToLower(strings.TrimSpace(s))]
+
+	if !ok {
+		return Unknown, errors.New(fmt.Sprintf("Unknown unit %q", s))
+	}
+
+	return u, nil
+}
+
+func (u Unit) String() string {
+	name, ok := unitNames[u]
+
+	if !ok {
+		return unitNames[Unknown]
+	}
+
+	return name
+}
+
+// ParseScaled parses the unit strings in CDO dataset documentation,
+// returning the Unit along with the scale of stored values, so that
+// "tenths of mm" is Millimeters with a scale of 0.1.  Strings without a
+// scale parse as Parse with a scale of 1.
+func ParseScaled(s string) (Unit, float64, error) {
+	lower := strings.ToLower(strings.TrimSpace(s))
+
+	for prefix, scale := range scales {
+		if strings.HasPrefix(lower, prefix) {
+			u, err := Parse(strings.TrimPrefix(lower, prefix))
+
+			if err != nil {
+				return Unknown, 0, errors.New(fmt.Sprintf("Unknown unit %q", s))
+			}
+
+			return u, scale, nil
+		}
+	}
+
+	u, err := Parse(s)
+	return u, 1, err
+}
+
+func (u Unit) Kind() Kind {
+	return unitKinds[u]
+}
+
+func (k Kind) String() string {
+	name, ok := kindNames[k]
+
+	if !ok {
+		return kindNames[KindUnknown]
+	}
+
+	return name
+}
+
+// Canonical returns the unit values of kind k are normalized to in the
+// system.  Kinds without a canonical unit return Unknown.
+func (s System) Canonical(k Kind) Unit {
+	switch k {
+	case KindTemperature:
+		if s == English {
+			return Fahrenheit
+		}
+
+		return Celsius
+	case KindLength:
+		if s == English {
+			return Inches
+		}
+
+		return Millimeters
+	case KindSpeed:
+		if s == English {
+			return Knots
+		}
+
+		return MetersPerSecond
+	case KindPressure:
+		if s == English {
+			return InchesOfMercury
+		}
+
+		return Hectopascals
+	case KindAngle:
+		return Degrees
+	case KindRatio:
+		return Percent
+	case KindCategory:
+		return Category
+	}
+
+	return Unknown
+}
+
+// Param returns the value of the NDFD Unit request parameter.
+func (s System) Param() string {
+	if s == English {
+		return "e"
+	}
+
+	return "m"
+}
+
+// factors hold the size of each unit of the kinds that convert by
+// scaling, in meters, meters per second, pascals, degrees and percent.
+var factors = map[Unit]float64{
+	Millimeters:       0.001,
+	Centimeters:       0.01,
+	Meters:            1,
+	Kilometers:        1000,
+	Inches:            0.0254,
+	Feet:              0.3048,
+	Miles:             1609.344,
+	MetersPerSecond:   1,
+	KilometersPerHour: 1000.0 / 3600.0,
+	MilesPerHour:      0.44704,
+	Knots:             1852.0 / 3600.0,
+	Pascals:           1,
+	Hectopascals:      100,
+	Kilopascals:       1000,
+	Millibars:         100,
+	InchesOfMercury:   3386.389,
+	Degrees:           1,
+	Radians:           180 / math.Pi,
+	Percent:           1,
+	Fraction:          100,
+}
+
+func Convert(v float64, from, to Unit) (float64, error) {
+	if from == to {
+		return v, nil
+	}
+
+	if from.Kind() != to.Kind() || from.Kind() == KindUnknown {
+		return v, errors.New(fmt.Sprintf("Cannot convert %s to %s", from, to))
+	}
+
+	if from.Kind() == KindTemperature {
+		return fromKelvin(toKelvin(v, from), to), nil
+	}
+
+	if factors[from] != 0 && factors[to] != 0 {
+		return v * factors[from] / factors[to], nil
+	}
+
+	return v, errors.New(fmt.Sprintf("Cannot convert %s to %s", from, to))
+}
+
+func toKelvin(v float64, u Unit) float64 {
+	switch u {
+	case Celsius:
+		return v + 273.15
+	case Fahrenheit:
+		return (v-32)*5/9 + 273.15
+	}
+
+	return v
+}
+
+func fromKelvin(v float64, u Unit) float64 {
+	switch u {
+	case Celsius:
+		return v - 273.15
+	case Fahrenheit:
+		return (v-273.15)*9/5 + 32
+	}
+
+	return v
+}
@@ -0,0 +1,77 @@
+package units
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]Unit{
+		"Celsius":       Celsius,
+		"Fahrenheit":    Fahrenheit,
+		"inches":        Inches,
+		"millimeters":   Millimeters,
+		"centimeters":   Centimeters,
+		"meters/second": MetersPerSecond,
+		" knots ":       Knots,
+		"degrees true":  Degrees,
+		"percent":       Percent,
+		"feet":          Feet,
+	}
+
+	for s, expected := range tests {
+		u, err := Parse(s)
+
+		if err != nil || u != expected {
+			t.Errorf("%q parsed as %s %v", s, u, err)
+		}
+	}
+
+	if _, err := Parse("furlongs/fortnight"); err == nil {
+		t.Errorf("Unknown units should not parse")
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		v        float64
+		from     Unit
+		to       Unit
+		expected float64
+	}{
+		{100, Celsius, Fahrenheit, 212},
+		{32, Fahrenheit, Celsius, 0},
+		{0, Celsius, Kelvin, 273.15},
+		{1, Inches, Millimeters, 25.4},
+		{10, Centimeters, Inches, 3.937007874},
+		{10, Knots, MetersPerSecond, 5.144444444},
+		{60, MilesPerHour, KilometersPerHour, 96.56064},
+	}
+
+	for _, test := range tests {
+		v, err := Convert(test.v, test.from, test.to)
+
+		if err != nil {
+			t.Errorf("%s", err)
+			continue
+		}
+
+		if math.Abs(v-test.expected) > 1e-6 {
+			t.Errorf("%v %s converted to %v %s, expected %v", test.v, test.from, v, test.to, test.expected)
+		}
+	}
+
+	if _, err := Convert(1, Celsius, Knots); err == nil {
+		t.Errorf("Celsius should not convert to knots")
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	if English.Canonical(KindTemperature) != Fahrenheit || Metric.Canonical(KindSpeed) != MetersPerSecond {
+		t.Errorf("Unexpected canonical units")
+	}
+
+	if English.Param() != "e" || Metric.Param() != "m" {
+		t.Errorf("Unexpected request parameters")
+	}
+}
@@ -0,0 +1,411 @@
+package verify
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gershwinlabs/noaa"
+	"github.com/gershwinlabs/noaa/cdo"
+	"github.com/gershwinlabs/noaa/ndfd"
+	"github.com/gershwinlabs/noaa/units"
+	"math"
+	"sort"
+	"time"
+)
+
+// Forecast is an archived NDFD Condition along with when the forecast
+// it came from was issued.
+type Forecast struct {
+	Condition ndfd.Condition
+	Issued    time.Time
+}
+
+// Observation is an observed value for the element with the condition
+// Name over the Valid time span.  Instantaneous observations have an
+// equal Begin and End.  Probabilistic observations verify a forecast
+// probability, and any positive value is the event occurring.
+type Observation struct {
+	Name          string
+	Lat           float64
+	Lon           float64
+	Valid         noaa.TimeSpan
+	Value         float64
+	Units         string
+	Probabilistic bool
+}
+
+// Pair is a forecast matched with the observation it verifies, in the
+// units of the observation.  Lead is the time from the issuance of the
+// forecast to the start of the observation.  Probabilistic pairs have a
+// percent probability forecast and an Observed of 1 when the event
+// occurred and 0 when it did not.
+type Pair struct {
+	Name          string
+	Lead          time.Duration
+	Forecast      float64
+	Observed      float64
+	Units         string
+	Probabilistic bool
+}
+
+type LeadBucket struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+// Scores summarize a set of pairs.  Brier and Reliability are only
+// computed for probabilistic pairs, and Brier is NaN for others.  The
+// errors of probabilistic pairs are of the probability as a fraction,
+// against 1 when the event occurred and 0 when it did not.
+type Scores struct {
+	Count       int
+	Bias        float64
+	MAE         float64
+	RMSE        float64
+	Brier       float64
+	Reliability []ReliabilityBin
+}
+
+// ReliabilityBin compares the mean forecast probability of the pairs
+// whose probability fell in [Low, High) with how often precipitation was
+// observed for them.
+type ReliabilityBin struct {
+	Low               float64
+	High              float64
+	Count             int
+	MeanForecast      float64
+	ObservedFrequency float64
+}
+
+type Result struct {
+	Name   string
+	Bucket LeadBucket
+	Scores Scores
+}
+
+var DefaultLeadBuckets = []LeadBucket{
+	{0, 24 * time.Hour},
+	{24 * time.Hour, 48 * time.Hour},
+	{48 * time.Hour, 72 * time.Hour},
+	{72 * time.Hour, 120 * time.Hour},
+	{120 * time.Hour, 168 * time.Hour},
+}
+
+// ghcndElements maps the GHCND datatypes that can verify an NDFD element
+// to the element and whether it is a probability.  Precipitation
+// verifies the probability of precipitation, as whether any fell.
+var ghcndElements = map[string]struct {
+	name          string
+	probabilistic bool
+}{
+	"TMAX": {"maxt", false},
+	"TMIN": {"mint", false},
+	"PRCP": {"pop12", true},
+}
+
+func (b LeadBucket) String() string {
+	return fmt.Sprintf("%.0f-%.0fh", b.Min.Hours(), b.Max.Hours())
+}
+
+func (b LeadBucket) contains(lead time.Duration) bool {
+	return lead >= b.Min && lead < b.Max
+}
+
+// Forecasts returns the Conditions of n, recording its CreationDate as
+// the issuance of each.
+func Forecasts(n ndfd.NDFD) []Forecast {
+	forecasts := make([]Forecast, 0)
+
+	for c := range n.Conditions.All() {
+		forecasts = append(forecasts, Forecast{c, n.CreationDate})
+	}
+
+	return forecasts
+}
+
+func ForecastsFrom(conds []ndfd.Condition, issued time.Time) []Forecast {
+	forecasts := make([]Forecast, len(conds))
+
+	for i, c := range conds {
+		forecasts[i] = Forecast{c, issued}
+	}
+
+	return forecasts
+}
+
+// FromGHCND converts a daily GHCND result to an Observation for the
+// station at lat, lon, valid for the whole day of the result.
+func FromGHCND(r cdo.Result, lat, lon float64) (Observation, error) {
+	el, ok := ghcndElements[r.Datatype]
+
+	if !ok {
+		return Observation{}, errors.New(fmt.Sprintf("No NDFD element for GHCND datatype %s", r.Datatype))
+	}
+
+	d, err := r.Datum(noaa.Location{Lat: lat, Lon: lon})
+
+	if err != nil {
+		return Observation{}, err
+	}
+
+	return Observation{el.name, lat, lon, d.Valid, d.Value, d.Unit.String(), el.probabilistic}, nil
+}
+
+// Pairs matches each observation with the forecasts of every issuance for
+// the same element within tolerance degrees of the observation.  When
+// an issuance forecast more than one value over the observation, the
+// value covering the most hours is used.  Probabilistic forecasts are
+// left as percentages, and when the observation spans several forecast
+// periods, such as the two 12 hour PoPs of a day, they are combined into
+// the probability of the event in any of them.
+func Pairs(forecasts []Forecast, obs []Observation, tolerance float64) ([]Pair, error) {
+	type hourKey struct {
+		name string
+		hour time.Time
+	}
+
+	type periodKey struct {
+		issued time.Time
+		period int
+	}
+
+	byHour := make(map[hourKey][]Forecast)
+
+	for _, f := range forecasts {
+		key := hourKey{f.Condition.Name, f.Condition.Hour.UTC()}
+		byHour[key] = append(byHour[key], f)
+	}
+
+	pairs := make([]Pair, 0)
+
+	for _, o := range obs {
+		counts := make(map[periodKey]map[float64]int)
+		periods := make(map[time.Time][]int)
+		issuances := make([]time.Time, 0)
+		forecastUnits := make(map[time.Time]string)
+		length := ndfd.ConditionPeriod(o.Name)
+
+		for _, hour := range o.Valid.Hours() {
+			period := 0
+
+			if o.Probabilistic && length > 0 {
+				period = int(hour.Sub(o.Valid.Begin) / length)
+			}
+
+			for _, f := range byHour[hourKey{o.Name, hour}] {
+				if math.Abs(f.Condition.Lat-o.Lat) > tolerance || math.Abs(f.Condition.Lon-o.Lon) > tolerance {
+					continue
+				}
+
+				issued := f.Issued.UTC()
+				key := periodKey{issued, period}
+
+				if _, ok := forecastUnits[issued]; !ok {
+					issuances = append(issuances, issued)
+					forecastUnits[issued] = f.Condition.Units
+				}
+
+				if _, ok := counts[key]; !ok {
+					counts[key] = make(map[float64]int)
+					periods[issued] = append(periods[issued], period)
+				}
+
+				counts[key][f.Condition.Value]++
+			}
+		}
+
+		for _, issued := range issuances {
+			lead := o.Valid.Begin.Sub(issued)
+
+			if o.Probabilistic {
+				// the percent chance of no event, kept in percent so that
+				// a single period's PoP is unchanged
+				dry := 100.0
+
+				for _, period := range periods[issued] {
+					dry = dry * (100 - dominantValue(counts[periodKey{issued, period}])) / 100
+				}
+
+				occurred := 0.0
+
+				if o.Value > 0 {
+					occurred = 1
+				}
+
+				pairs = append(pairs, Pair{o.Name, lead, 100 - dry, occurred, forecastUnits[issued], true})
+				continue
+			}
+
+			value := dominantValue(counts[periodKey{issued, 0}])
+			converted, err := convert(value, forecastUnits[issued], o.Units)
+
+			if err != nil {
+				return pairs, err
+			}
+
+			pairs = append(pairs, Pair{o.Name, lead, converted, o.Value, o.Units, false})
+		}
+	}
+
+	return pairs, nil
+}
+
+// Verify scores the pairs for each element and lead bucket.  Pairs whose
+// lead time is not in any bucket are ignored.
+func Verify(pairs []Pair, buckets []LeadBucket) []Result {
+	type resultKey struct {
+		name   string
+		bucket int
+	}
+
+	grouped := make(map[resultKey][]Pair)
+
+	for _, p := range pairs {
+		for i, b := range buckets {
+			if b.contains(p.Lead) {
+				key := resultKey{p.Name, i}
+				grouped[key] = append(grouped[key], p)
+			}
+		}
+	}
+
+	keys := make([]resultKey, 0, len(grouped))
+
+	for key := range grouped {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].name != keys[j].name {
+			return keys[i].name < keys[j].name
+		}
+
+		return keys[i].bucket < keys[j].bucket
+	})
+
+	results := make([]Result, len(keys))
+
+	for i, key := range keys {
+		results[i] = Result{key.name, buckets[key.bucket], Score(grouped[key])}
+	}
+
+	return results
+}
+
+func Score(pairs []Pair) Scores {
+	s := Scores{Count: len(pairs), Bias: math.NaN(), MAE: math.NaN(), RMSE: math.NaN(), Brier: math.NaN()}
+
+	if len(pairs) == 0 {
+		return s
+	}
+
+	var sum, sumAbs, sumSq float64
+
+	for _, p := range pairs {
+		forecast := p.Forecast
+
+		if p.Probabilistic {
+			forecast /= 100
+		}
+
+		e := forecast - p.Observed
+		sum += e
+		sumAbs += math.Abs(e)
+		sumSq += e * e
+	}
+
+	n := float64(len(pairs))
+	s.Bias = sum / n
+	s.MAE = sumAbs / n
+	s.RMSE = math.Sqrt(sumSq / n)
+
+	if pairs[0].Probabilistic {
+		s.Brier, s.Reliability = probabilityScores(pairs, 10)
+	}
+
+	return s
+}
+
+// probabilityScores treats the forecasts as percent probabilities and an
+// observation of 1 as the event occurring.
+func probabilityScores(pairs []Pair, numBins int) (float64, []ReliabilityBin) {
+	bins := make([]ReliabilityBin, numBins)
+	width := 1.0 / float64(numBins)
+
+	for i := range bins {
+		bins[i].Low = float64(i) * width
+		bins[i].High = float64(i+1) * width
+	}
+
+	var brier float64
+
+	for _, p := range pairs {
+		prob := p.Forecast / 100
+		occurred := 0.0
+
+		if p.Observed > 0 {
+			occurred = 1
+		}
+
+		brier += (prob - occurred) * (prob - occurred)
+		i := int(p.Forecast * float64(numBins) / 100)
+
+		if i >= numBins {
+			i = numBins - 1
+		}
+
+		if i < 0 {
+			i = 0
+		}
+
+		bins[i].Count++
+		bins[i].MeanForecast += prob
+		bins[i].ObservedFrequency += occurred
+	}
+
+	for i := range bins {
+		if bins[i].Count == 0 {
+			bins[i].MeanForecast = math.NaN()
+			bins[i].ObservedFrequency = math.NaN()
+			continue
+		}
+
+		bins[i].MeanForecast /= float64(bins[i].Count)
+		bins[i].ObservedFrequency /= float64(bins[i].Count)
+	}
+
+	return brier / float64(len(pairs)), bins
+}
+
+func dominantValue(counts map[float64]int) float64 {
+	best := math.NaN()
+	bestCount := 0
+
+	for v, count := range counts {
+		if count > bestCount || (count == bestCount && v < best) {
+			best = v
+			bestCount = count
+		}
+	}
+
+	return best
+}
+
+func convert(v float64, from, to string) (float64, error) {
+	if from == to || to == "" {
+		return v, nil
+	}
+
+	fromUnit, err := units.Parse(from)
+
+	if err != nil {
+		return v, err
+	}
+
+	toUnit, err := units.Parse(to)
+
+	if err != nil {
+		return v, err
+	}
+
+	return units.Convert(v, fromUnit, toUnit)
+}
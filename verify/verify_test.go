@@ -0,0 +1,147 @@
+package verify
+
+import (
+	"github.com/gershwinlabs/noaa"
+	"github.com/gershwinlabs/noaa/cdo"
+	"github.com/gershwinlabs/noaa/ndfd"
+	"math"
+	"testing"
+	"time"
+)
+
+func hourly(name string, begin time.Time, hours int, value float64, units string) []ndfd.Condition {
+	conds := make([]ndfd.Condition, hours)
+
+	for i := range conds {
+		conds[i] = ndfd.Condition{Name: name, Value: value, Units: units, Hour: begin.Add(time.Duration(i) * time.Hour), Lat: 39.64, Lon: -106.37, Location: "point1"}
+	}
+
+	return conds
+}
+
+func TestPairsAndScores(t *testing.T) {
+	issued := time.Date(2016, 3, 1, 0, 0, 0, 0, time.UTC)
+	day := time.Date(2016, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	// the maxt period for the 2nd covers most of the day, with the end of
+	// the 1st's period spilling over its first two hours
+	conds := hourly("maxt", day, 2, 5, "Celsius")
+	conds = append(conds, hourly("maxt", day.Add(14*time.Hour), 12, 4, "Celsius")...)
+	forecasts := ForecastsFrom(conds, issued)
+	forecasts = append(forecasts, ForecastsFrom(hourly("maxt", day.Add(14*time.Hour), 12, 35, "Fahrenheit"), issued.Add(12*time.Hour))...)
+
+	obs, err := FromGHCND(cdo.Result{Datatype: "TMAX", Date: "2016-03-02T00:00:00", Value: 20}, 39.65, -106.36)
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	pairs, err := Pairs(forecasts, []Observation{obs}, 0.05)
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	if len(pairs) != 2 || pairs[0].Forecast != 4 || pairs[0].Lead != 24*time.Hour || pairs[0].Observed != 2 {
+		t.Fatalf("Unexpected pairs %+v", pairs)
+	}
+
+	if math.Abs(pairs[1].Forecast-(5.0/3.0)) > 1e-9 {
+		t.Errorf("Fahrenheit forecast converted to %f", pairs[1].Forecast)
+	}
+
+	results := Verify(pairs, DefaultLeadBuckets)
+
+	if len(results) != 2 || results[0].Bucket != DefaultLeadBuckets[0] || results[1].Scores.Bias != 2 || results[1].Scores.MAE != 2 {
+		t.Errorf("Unexpected results %+v", results)
+	}
+
+	if _, err := FromGHCND(cdo.Result{Datatype: "AWND", Date: "2016-03-02T00:00:00"}, 0, 0); err == nil {
+		t.Errorf("AWND should not convert to an observation")
+	}
+}
+
+func TestProbabilityScores(t *testing.T) {
+	pairs := []Pair{
+		{"pop12", time.Hour, 100, 1, "percent", true},
+		{"pop12", time.Hour, 0, 0, "percent", true},
+		{"pop12", time.Hour, 50, 0, "percent", true},
+		{"pop12", time.Hour, 50, 2, "percent", true},
+	}
+
+	s := Score(pairs)
+
+	if s.Brier != 0.125 {
+		t.Errorf("Brier score computed as %f", s.Brier)
+	}
+
+	if len(s.Reliability) != 10 || s.Reliability[5].Count != 2 || s.Reliability[5].ObservedFrequency != 0.5 || s.Reliability[9].MeanForecast != 1 {
+		t.Errorf("Unexpected reliability %+v", s.Reliability)
+	}
+
+	if s := Score([]Pair{{"pop12", time.Hour, 90, 1, "percent", true}}); math.Abs(s.Bias+0.1) > 1e-9 || math.Abs(s.MAE-0.1) > 1e-9 || math.Abs(s.RMSE-0.1) > 1e-9 {
+		t.Errorf("Errors of a 90%% forecast of an event computed as %+v", s)
+	}
+
+	if !math.IsNaN(Score([]Pair{{"temp", time.Hour, 1, 2, "Celsius", false}}).Brier) {
+		t.Errorf("Brier score computed for temperature")
+	}
+
+	span := noaa.TimeSpan{Begin: time.Date(2016, 3, 2, 0, 0, 0, 0, time.UTC), End: time.Date(2016, 3, 2, 12, 0, 0, 0, time.UTC)}
+
+	if _, err := Pairs([]Forecast{}, []Observation{{"pop12", 0, 0, span, 1, "", true}}, 0.1); err != nil {
+		t.Errorf("%s", err)
+	}
+}
+
+func TestPoPPairs(t *testing.T) {
+	day := time.Date(2016, 3, 2, 0, 0, 0, 0, time.UTC)
+	issued := day.Add(-24 * time.Hour)
+
+	// the wet day is forecast at 90% for its first half and 10% for its
+	// second, and the dry day at 20% and then 0%
+	forecasts := ForecastsFrom(hourly("pop12", day, 12, 90, "percent"), issued)
+	forecasts = append(forecasts, ForecastsFrom(hourly("pop12", day.Add(12*time.Hour), 12, 10, "percent"), issued)...)
+	forecasts = append(forecasts, ForecastsFrom(hourly("pop12", day.Add(24*time.Hour), 12, 20, "percent"), issued)...)
+	forecasts = append(forecasts, ForecastsFrom(hourly("pop12", day.Add(36*time.Hour), 12, 0, "percent"), issued)...)
+	obs := make([]Observation, 0)
+
+	for _, r := range []cdo.Result{
+		{Datatype: "PRCP", Date: "2016-03-02T00:00:00", Value: 56},
+		{Datatype: "PRCP", Date: "2016-03-03T00:00:00", Value: 0},
+	} {
+		o, err := FromGHCND(r, 39.65, -106.36)
+
+		if err != nil {
+			t.Fatalf("%s", err)
+		}
+
+		obs = append(obs, o)
+	}
+
+	pairs, err := Pairs(forecasts, obs, 0.05)
+
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+
+	// 1 - (1 - 0.9)(1 - 0.1) and 1 - (1 - 0.2)(1 - 0)
+	if len(pairs) != 2 || math.Abs(pairs[0].Forecast-91) > 1e-9 || pairs[0].Observed != 1 || math.Abs(pairs[1].Forecast-20) > 1e-9 || pairs[1].Observed != 0 {
+		t.Fatalf("Unexpected pairs %+v", pairs)
+	}
+
+	s := Score(pairs)
+
+	// ((0.91 - 1)^2 + (0.2 - 0)^2) / 2
+	if math.Abs(s.Brier-0.02405) > 1e-9 {
+		t.Errorf("Brier score computed as %f", s.Brier)
+	}
+
+	if math.Abs(s.Bias-0.055) > 1e-9 || math.Abs(s.MAE-0.145) > 1e-9 {
+		t.Errorf("Bias and MAE computed as %f and %f", s.Bias, s.MAE)
+	}
+
+	if s.Reliability[9].Count != 1 || s.Reliability[9].ObservedFrequency != 1 || s.Reliability[2].ObservedFrequency != 0 {
+		t.Errorf("Unexpected reliability %+v", s.Reliability)
+	}
+}